@@ -0,0 +1,89 @@
+package enhance
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dlvhdr/gh-enhance/internal/tui"
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [<job-url> | <run-url> | <pr-url> | <pr-number>] [flags]",
+	Short: "Print the parsed logs of jobs",
+	Long: "Print the logs of the jobs of a PR or a run, cleaned up the same way the UI shows them.\n" +
+		"Colors are kept when piping if CLICOLOR_FORCE=1 is set.",
+	Args: cobra.MaximumNArgs(1),
+	Example: `# print the logs of a job
+ gh enhance logs https://github.com/dlvhdr/gh-dash/actions/runs/23687980056/job/68741234567
+
+ # print only the failed steps of the failed jobs of PR 767
+ gh enhance logs 767 --failed
+
+ # print the logs of the "Run tests" step of the lint job of a run, with timestamps
+ gh enhance logs --run 23687980056 --job lint --step "run tests" --timestamps
+
+ # search the original logs of a run
+ gh enhance logs https://github.com/dlvhdr/gh-dash/actions/runs/23687980056 --raw | grep panic
+
+ # page through the logs of a PR with colors
+ CLICOLOR_FORCE=1 gh enhance logs 767 | less -R`,
+}
+
+func init() {
+	logsCmd.Flags().String("run", "", "print the logs of a workflow run by its numeric ID")
+	logsCmd.Flags().StringP("job", "j", "", "only print the logs of jobs with this ID or name")
+	logsCmd.Flags().StringP("step", "s", "", "only print the logs of steps with this number or name")
+	logsCmd.Flags().Bool("failed", false, "only print the failed steps of failed jobs")
+	logsCmd.Flags().Bool("raw", false, "print the logs as they are returned by GitHub")
+	logsCmd.Flags().Bool("timestamps", false, "prefix every line with its timestamp")
+
+	logsCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		runID, err := cmd.Flags().GetString("run")
+		if err != nil {
+			return err
+		}
+		if runID != "" && len(args) > 0 {
+			return errors.New("cannot pass both --run and a positional argument")
+		}
+		repo, err := cmd.Flags().GetString("repo")
+		if err != nil {
+			return err
+		}
+
+		opts, err := resolveTarget(args, repo, runID)
+		if err != nil {
+			return err
+		}
+		if opts.PRNumber == "" && opts.RunID == "" {
+			return errors.New("pass a job URL, a run URL, a PR or --run to print logs of")
+		}
+
+		lo := tui.LogsOpts{Out: os.Stdout, JobID: opts.JobID}
+		for name, value := range map[string]*string{"job": &lo.Job, "step": &lo.Step} {
+			if *value, err = cmd.Flags().GetString(name); err != nil {
+				return err
+			}
+		}
+		for name, value := range map[string]*bool{
+			"failed":     &lo.Failed,
+			"raw":        &lo.Raw,
+			"timestamps": &lo.Timestamps,
+		} {
+			if *value, err = cmd.Flags().GetBool(name); err != nil {
+				return err
+			}
+		}
+
+		// a step anchor of a job URL selects the step unless --step is passed
+		if lo.Step == "" && opts.Step != 0 {
+			lo.Step = strconv.Itoa(opts.Step)
+		}
+
+		return tui.PrintLogs(opts, lo)
+	}
+
+	rootCmd.AddCommand(logsCmd)
+}
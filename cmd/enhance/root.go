@@ -10,15 +10,19 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"charm.land/log/v2"
 	"github.com/charmbracelet/colorprofile"
-	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/spf13/cobra"
 
 	"github.com/charmbracelet/fang"
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/export"
 	"github.com/dlvhdr/gh-enhance/internal/tui"
 	"github.com/dlvhdr/gh-enhance/internal/version"
 )
@@ -32,22 +36,50 @@ var (
 	prURLPattern = regexp.MustCompile(
 		`^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/pull/(?P<number>\d+)`,
 	)
+	jobURLPattern = regexp.MustCompile(
+		`^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/actions/runs/(?P<runID>\d+)/job/(?P<jobID>\d+)`,
+	)
 	runURLPattern = regexp.MustCompile(
 		`^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/actions/runs/(?P<runID>\d+)`,
 	)
+	checkRunURLPattern = regexp.MustCompile(
+		`^/(?P<owner>[^/]+)/(?P<repo>[^/]+)/runs/(?P<checkRunID>\d+)`,
+	)
+	// the anchor of a step, or of a line in a step, in the logs of a job
+	stepAnchorPattern = regexp.MustCompile(`^step:(?P<step>\d+)(?::(?P<line>\d+))?$`)
 )
 
+var usage = lipgloss.JoinVertical(lipgloss.Left,
+	lipgloss.NewStyle().
+		Bold(true).
+		Render("Usage:")+
+		" `"+
+		lipgloss.NewStyle().
+			Foreground(lipgloss.Green).
+			Render("gh enhance")+
+		" https://github.com/owner/repo/pull/15623`.",
+	"Run "+
+		lipgloss.NewStyle().
+			Background(lipgloss.Color("#141417")).
+			Render("`gh enhance --help`")+
+		" for help and examples.\n")
+
 var rootCmd = &cobra.Command{
-	Use:   "gh enhance [<pr-url> | <pr-number> | <run-url>] [flags]",
+	Use:   "enhance [<pr-url> | <pr-number> | <run-url>] [flags]",
 	Long:  logoWithTagline,
 	Short: "A Blazingly Fast Terminal UI for GitHub Actions",
 	Args:  cobra.MinimumNArgs(0),
+	// so subcommands show up as "gh enhance logs" rather than "gh logs"
+	Annotations: map[string]string{cobra.CommandDisplayNameAnnotation: "gh enhance"},
 	Example: `# watch all recent runs of the current git repo
 	gh enhance
 
 	# watch all recent runs of the specified repo
   gh enhance -R neovim/neovim 
 
+ # watch only your failed runs on the main branch
+ gh enhance --actor dlvhdr --branch main --status failure
+
 	# look up via a full URL to a GitHub PR
   gh enhance https://github.com/dlvhdr/gh-dash/pull/767
 
@@ -55,11 +87,23 @@ var rootCmd = &cobra.Command{
  # will look at checks of https://github.com/dlvhdr/gh-dash/pull/767
  gh enhance 767
 
+ # wait for the checks of PR 767 to pass before pushing again
+ gh enhance 767 --watch && git push
+
+ # list the failed jobs of PR 767 in a script
+ gh enhance 767 --json jobs --template '{{range .}}{{range .jobs}}{{if eq .bucket "fail"}}{{.name}}{{"\n"}}{{end}}{{end}}{{end}}'
+
  # look up via a full URL to a GitHub Actions run
  gh enhance https://github.com/dlvhdr/gh-dash/actions/runs/23687980056
 
+ # land on a failing line of a job, as linked to from its logs on GitHub
+ gh enhance "https://github.com/dlvhdr/gh-dash/actions/runs/23687980056/job/68741234567#step:4:12"
+
  # look up via a run ID (--run disambiguates from PR numbers)
- gh enhance --run 23687980056`,
+ gh enhance --run 23687980056
+
+ # watch all recent runs of a repo on a GitHub Enterprise Server instance
+ gh enhance -R github.example.com/owner/repo`,
 }
 
 func Execute() error {
@@ -131,6 +175,91 @@ func init() {
 		"look up a workflow run by its numeric ID",
 	)
 
+	rootCmd.Flags().String(
+		"job",
+		"",
+		"select the job with this ID on start, with a PR or a run",
+	)
+
+	rootCmd.Flags().Int(
+		"step",
+		0,
+		"select the step with this number of the selected job on start",
+	)
+
+	rootCmd.Flags().Int(
+		"line",
+		0,
+		"scroll to this log line of the selected job on start, relative to --step if passed",
+	)
+
+	rootCmd.Flags().String(
+		"branch",
+		"",
+		"only show repo runs of this branch",
+	)
+
+	rootCmd.Flags().String(
+		"actor",
+		"",
+		"only show repo runs triggered by this user",
+	)
+
+	rootCmd.Flags().String(
+		"event",
+		"",
+		"only show repo runs triggered by this event, e.g. push or pull_request",
+	)
+
+	rootCmd.Flags().String(
+		"status",
+		"",
+		"only show repo runs with this status or conclusion, e.g. failure or in_progress",
+	)
+
+	rootCmd.Flags().String(
+		"workflow",
+		"",
+		"only show repo runs of this workflow, by file name or ID, e.g. ci.yml",
+	)
+
+	rootCmd.Flags().String(
+		"created",
+		"",
+		"only show repo runs created at this date or range, e.g. >=2025-01-01",
+	)
+
+	rootCmd.Flags().String(
+		"sha",
+		"",
+		"only show repo runs of this commit, with --watch defaults to the latest commit with runs",
+	)
+
+	rootCmd.Flags().Bool(
+		"watch",
+		false,
+		"print the status of the checks without the UI until they conclude, exiting 1 if any failed",
+	)
+
+	rootCmd.Flags().Duration(
+		"timeout",
+		0,
+		"with --watch, stop waiting after this duration and exit 8 if checks are still pending",
+	)
+
+	rootCmd.Flags().StringSlice(
+		"json",
+		nil,
+		"print the runs as JSON with the specified fields, e.g. name,bucket,jobs",
+	)
+
+	rootCmd.Flags().StringP(
+		"template",
+		"t",
+		"",
+		"format the JSON output using a Go template",
+	)
+
 	rootCmd.Flags().Bool(
 		"debug",
 		false,
@@ -144,23 +273,7 @@ func init() {
 		"help for enhance",
 	)
 
-	usage := lipgloss.JoinVertical(lipgloss.Left,
-		lipgloss.NewStyle().
-			Bold(true).
-			Render("Usage:")+
-			" `"+
-			lipgloss.NewStyle().
-				Foreground(lipgloss.Green).
-				Render("gh enhance")+
-			" https://github.com/owner/repo/pull/15623`.",
-		"Run "+
-			lipgloss.NewStyle().
-				Background(lipgloss.Color("#141417")).
-				Render("`gh enhance --help`")+
-			" for help and examples.\n")
-
 	rootCmd.RunE = func(_ *cobra.Command, args []string) error {
-		isRunMode := false
 		var runID string
 
 		// Check --run flag first (string flag takes the run ID directly)
@@ -171,62 +284,70 @@ func init() {
 				return errors.New("run ID is not a number")
 			}
 			runID = runFlagVal
-			isRunMode = true
 
 			if len(args) > 0 {
 				return errors.New("cannot pass both --run and a positional argument")
 			}
 		}
 
-		opts := tui.ModelOpts{}
-
-		if !isRunMode && len(args) > 0 {
-			arg := args[0]
-			if u, err := url.Parse(arg); err == nil && u.Hostname() == "github.com" {
-				if m := runURLPattern.FindStringSubmatch(u.Path); m != nil {
-					repo = m[runURLPattern.SubexpIndex("owner")] + "/" +
-						m[runURLPattern.SubexpIndex("repo")]
-					runID = m[runURLPattern.SubexpIndex("runID")]
-					isRunMode = true
-				} else if m := prURLPattern.FindStringSubmatch(u.Path); m != nil {
-					repo = m[prURLPattern.SubexpIndex("owner")] + "/" +
-						m[prURLPattern.SubexpIndex("repo")]
-					opts.PRNumber = m[prURLPattern.SubexpIndex("number")]
-				} else {
-					fmt.Print(usage)
-					return errors.New("bad URL passed")
-				}
-			} else {
-				// Bare number - must be a PR number
-				if _, err := strconv.Atoi(arg); err != nil {
-					fmt.Print(usage)
-					return errors.New("PR number is not a number")
-				}
-				opts.PRNumber = arg
-			}
+		opts, err := resolveTarget(args, repo, runID)
+		if err != nil {
+			return err
+		}
+		if err := deepLinkFromFlags(&opts); err != nil {
+			return err
 		}
 
-		if repo == "" {
-			r, err := gh.CurrentRepository()
-			if err == nil {
-				repo = r.Owner() + "/" + r.Name()
-			}
+		flat, err := rootCmd.Flags().GetBool("flat")
+		if err != nil {
+			return err
 		}
+		opts.Flat = flat
 
-		if repo == "" {
-			fmt.Print(usage)
-			return errors.New("could not determine repository; use -R owner/repo to specify it")
+		filters, err := repoFiltersFromFlags()
+		if err != nil {
+			return err
+		}
+		if !filters.IsEmpty() && (opts.RunID != "" || opts.PRNumber != "") {
+			return errors.New("run filters can only be used when watching a repo's runs")
 		}
-		opts.Repo = repo
+		opts.Filters = filters
 
-		flat, err := rootCmd.Flags().GetBool("flat")
+		watch, err := rootCmd.Flags().GetBool("watch")
 		if err != nil {
 			return err
 		}
-		opts.Flat = flat
+		timeout, err := rootCmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return err
+		}
+		if watch && (rootCmd.Flags().Changed("json") || rootCmd.Flags().Changed("template")) {
+			return errors.New("--watch cannot be used with --json or --template")
+		}
+		if timeout != 0 && !watch {
+			return errors.New("--timeout can only be used with --watch")
+		}
+		if watch {
+			return watchHeadless(opts, timeout)
+		}
 
-		if isRunMode {
-			opts.RunID = runID
+		jsonFields, err := rootCmd.Flags().GetStringSlice("json")
+		if err != nil {
+			return err
+		}
+		tmpl, err := rootCmd.Flags().GetString("template")
+		if err != nil {
+			return err
+		}
+		if rootCmd.Flags().Changed("json") || tmpl != "" {
+			if err := export.ValidateFields(jsonFields); err != nil {
+				return err
+			}
+			runs, err := tui.FetchRuns(opts)
+			if err != nil {
+				return err
+			}
+			return export.Print(os.Stdout, runs, jsonFields, tmpl)
 		}
 
 		p := tea.NewProgram(tui.NewModel(opts))
@@ -239,6 +360,167 @@ func init() {
 	}
 }
 
+// resolveTarget resolves the PR, run or job passed as an argument, falling back to
+// the runs of the repo from the -R flag or the current git repo.
+func resolveTarget(args []string, repo string, runID string) (tui.ModelOpts, error) {
+	opts := tui.ModelOpts{}
+	var host string
+	var checkRunID string
+
+	if runID == "" && len(args) > 0 {
+		arg := args[0]
+		if u, err := url.Parse(arg); err == nil && u.Host != "" {
+			host = u.Host
+			if m := jobURLPattern.FindStringSubmatch(u.Path); m != nil {
+				repo = m[jobURLPattern.SubexpIndex("owner")] + "/" +
+					m[jobURLPattern.SubexpIndex("repo")]
+				runID = m[jobURLPattern.SubexpIndex("runID")]
+				opts.JobID = m[jobURLPattern.SubexpIndex("jobID")]
+			} else if m := runURLPattern.FindStringSubmatch(u.Path); m != nil {
+				repo = m[runURLPattern.SubexpIndex("owner")] + "/" +
+					m[runURLPattern.SubexpIndex("repo")]
+				runID = m[runURLPattern.SubexpIndex("runID")]
+			} else if m := checkRunURLPattern.FindStringSubmatch(u.Path); m != nil {
+				repo = m[checkRunURLPattern.SubexpIndex("owner")] + "/" +
+					m[checkRunURLPattern.SubexpIndex("repo")]
+				checkRunID = m[checkRunURLPattern.SubexpIndex("checkRunID")]
+			} else if m := prURLPattern.FindStringSubmatch(u.Path); m != nil {
+				repo = m[prURLPattern.SubexpIndex("owner")] + "/" +
+					m[prURLPattern.SubexpIndex("repo")]
+				opts.PRNumber = m[prURLPattern.SubexpIndex("number")]
+				// links to a check from the checks tab of a PR
+				opts.JobID = u.Query().Get("check_run_id")
+			} else {
+				fmt.Print(usage)
+				return opts, errors.New("bad URL passed")
+			}
+
+			if m := stepAnchorPattern.FindStringSubmatch(u.Fragment); m != nil {
+				opts.Step, _ = strconv.Atoi(m[stepAnchorPattern.SubexpIndex("step")])
+				opts.Line, _ = strconv.Atoi(m[stepAnchorPattern.SubexpIndex("line")])
+			}
+		} else {
+			// Bare number - must be a PR number
+			if _, err := strconv.Atoi(arg); err != nil {
+				fmt.Print(usage)
+				return opts, errors.New("PR number is not a number")
+			}
+			opts.PRNumber = arg
+		}
+	}
+
+	// the repo came from the -R flag which may include a host
+	if repo != "" && host == "" {
+		r, err := repository.Parse(repo)
+		if err != nil {
+			fmt.Print(usage)
+			return opts, err
+		}
+		host = r.Host
+		repo = r.Owner + "/" + r.Name
+	}
+
+	if repo == "" {
+		r, err := repository.Current()
+		if err == nil {
+			host = r.Host
+			repo = r.Owner + "/" + r.Name
+		}
+	}
+
+	if repo == "" {
+		fmt.Print(usage)
+		return opts, errors.New("could not determine repository; use -R owner/repo to specify it")
+	}
+
+	// check run URLs don't include the run, but the jobs of runs are check runs
+	if checkRunID != "" {
+		client := api.New(host)
+		job, err := client.FetchJob(repo, checkRunID)
+		if err != nil {
+			return opts, fmt.Errorf("check run %s isn't a job of a workflow run: %w", checkRunID, err)
+		}
+		runID = strconv.Itoa(job.RunId)
+		opts.JobID = checkRunID
+	}
+
+	opts.Repo = repo
+	opts.Host = host
+	opts.RunID = runID
+
+	return opts, nil
+}
+
+func watchHeadless(opts tui.ModelOpts, timeout time.Duration) error {
+	t := term.FromEnv()
+	width, _, _ := t.Size()
+	code, err := tui.Watch(opts, tui.WatchOpts{
+		Out:     t.Out(),
+		IsTTY:   t.IsTerminalOutput(),
+		Width:   width,
+		Timeout: timeout,
+	})
+	if err != nil {
+		return err
+	}
+	if code != tui.WatchExitPass {
+		os.Exit(code)
+	}
+	return nil
+}
+
+// deepLinkFromFlags overrides the job, step and line linked to with the flags.
+func deepLinkFromFlags(opts *tui.ModelOpts) error {
+	job, err := rootCmd.Flags().GetString("job")
+	if err != nil {
+		return err
+	}
+	if job != "" {
+		if _, err := strconv.Atoi(job); err != nil {
+			return errors.New("job ID is not a number")
+		}
+		opts.JobID = job
+	}
+
+	for name, value := range map[string]*int{"step": &opts.Step, "line": &opts.Line} {
+		if !rootCmd.Flags().Changed(name) {
+			continue
+		}
+		if *value, err = rootCmd.Flags().GetInt(name); err != nil {
+			return err
+		}
+	}
+
+	if opts.JobID == "" && (opts.Step != 0 || opts.Line != 0) {
+		return errors.New("--step and --line need a job, pass a job URL or --job")
+	}
+	if opts.JobID != "" && opts.RunID == "" && opts.PRNumber == "" {
+		return errors.New("--job can only be used with a PR or a run")
+	}
+	return nil
+}
+
+func repoFiltersFromFlags() (api.RepoWorkflowRunsFilters, error) {
+	filters := api.RepoWorkflowRunsFilters{}
+	for name, value := range map[string]*string{
+		"branch":   &filters.Branch,
+		"actor":    &filters.Actor,
+		"event":    &filters.Event,
+		"status":   &filters.Status,
+		"workflow": &filters.Workflow,
+		"created":  &filters.Created,
+		"sha":      &filters.HeadSHA,
+	} {
+		v, err := rootCmd.Flags().GetString(name)
+		if err != nil {
+			return filters, err
+		}
+		*value = v
+	}
+
+	return filters, filters.Validate()
+}
+
 func setDebugLogLevel() {
 	switch os.Getenv("LOG_LEVEL") {
 	case "debug", "":
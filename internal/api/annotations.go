@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type AnnotationLevel string
+
+const (
+	AnnotationNotice  AnnotationLevel = "notice"
+	AnnotationWarning AnnotationLevel = "warning"
+	AnnotationFailure AnnotationLevel = "failure"
+)
+
+// Annotation is a message a check run attached to the lines of a file, e.g. a lint
+// or compile error. Annotations that aren't about a file have a path of ".github".
+// REST API response for GET /repos/{owner}/{repo}/check-runs/{check_run_id}/annotations
+// https://docs.github.com/en/rest/checks/runs#list-check-run-annotations
+type Annotation struct {
+	Path        string          `json:"path"`
+	StartLine   int             `json:"start_line"`
+	EndLine     int             `json:"end_line"`
+	StartColumn int             `json:"start_column"`
+	Level       AnnotationLevel `json:"annotation_level"`
+	Title       string          `json:"title"`
+	Message     string          `json:"message"`
+}
+
+func (a *API) FetchAnnotations(repo string, checkRunId string) ([]Annotation, error) {
+	res := make([]Annotation, 0)
+	err := a.doREST(
+		http.MethodGet,
+		fmt.Sprintf("repos/%s/check-runs/%s/annotations?per_page=100", repo, checkRunId),
+		nil,
+		&res,
+	)
+	return res, err
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -63,33 +64,57 @@ type Conclusion string
 type CheckRunState string
 
 type API struct {
-	url        string
+	host       string
+	url        string // REST API base URL
+	webURL     string
 	gqlClient  *gh.GraphQLClient
 	httpClient *http.Client
 }
 
-const (
-	defaultAPIURL    = "https://api.github.com"
-	defaultServerURL = "https://github.com"
-)
-
-func New() API {
-	apiURL := os.Getenv("GITHUB_API_URL")
-	if apiURL == "" {
-		apiURL = defaultAPIURL
-	}
-
-	a := API{}
+// New creates an API for the given host, e.g. github.com or a GHES hostname.
+// An empty host is resolved from GH_HOST or the gh config.
+func New(host string) API {
+	a := newAPI(ResolveHost(host), host == "")
 
 	// initialize singletons
 	a.getHTTPClient()
 	a.getGraphQLClient()
 
-	a.url = apiURL
+	return a
+}
+
+// newAPI creates an API for a host. GITHUB_API_URL, as set in GitHub Actions, overrides
+// the REST URL only when the host was resolved rather than passed, as it points at the
+// host the workflow runs on.
+func newAPI(host string, resolvedHost bool) API {
+	apiURL := ""
+	if resolvedHost {
+		apiURL = os.Getenv("GITHUB_API_URL")
+	}
+	if apiURL == "" {
+		apiURL = restURL(host)
+	}
+
+	a := API{
+		host:   host,
+		url:    strings.TrimSuffix(apiURL, "/"),
+		webURL: webURL(host),
+	}
+	log.Debug("api urls", "host", a.host, "rest", a.url, "web", a.webURL)
 
 	return a
 }
 
+// Host returns the hostname the API talks to, e.g. github.com
+func (a *API) Host() string {
+	return a.host
+}
+
+// WebURL returns the base URL of the web UI, e.g. https://github.com
+func (a *API) WebURL() string {
+	return a.webURL
+}
+
 func IsFailureConclusion(c Conclusion) bool {
 	switch c {
 	case ConclusionActionRequired, ConclusionFailure,
@@ -124,7 +149,8 @@ type CheckSuite struct {
 					Name string
 				}
 			}
-		} `graphql:"pendingDeploymentRequests(first: 1)"`
+			PageInfo PageInfo
+		} `graphql:"pendingDeploymentRequests(first: 10)"`
 		Workflow struct {
 			Name string
 		}
@@ -137,6 +163,8 @@ type StatusContext struct {
 	Context     string
 	Description string
 	State       Conclusion
+	TargetUrl   string
+	CreatedAt   time.Time
 }
 
 // CheckRun is a job running in CI on a specific commit. It is part of a CheckSuite.
@@ -160,7 +188,8 @@ type CheckRunWithSteps struct {
 	DatabaseId int
 	Url        string
 	Steps      struct {
-		Nodes []Step
+		Nodes    []Step
+		PageInfo PageInfo
 	} `graphql:"steps(first: 100)"`
 }
 
@@ -263,15 +292,7 @@ func (a *API) getGraphQLClient() (*gh.GraphQLClient, error) {
 		return a.gqlClient, nil
 	}
 
-	level := os.Getenv("LOG_LEVEL")
-	opts := gh.ClientOptions{}
-	if level == "debug" {
-		logger := NewHTTPLogger(0)
-		opts.Log = &logger
-		opts.LogVerboseHTTP = true
-		opts.LogColorize = true
-	}
-	a.gqlClient, err = gh.NewGraphQLClient(opts)
+	a.gqlClient, err = gh.NewGraphQLClient(a.clientOptions())
 	return a.gqlClient, err
 }
 
@@ -280,17 +301,56 @@ func (a *API) getHTTPClient() (*http.Client, error) {
 	if a.httpClient != nil {
 		return a.httpClient, nil
 	}
-	level := os.Getenv("LOG_LEVEL")
-	opts := gh.ClientOptions{}
-	if level == "debug" {
+	a.httpClient, err = gh.NewHTTPClient(a.clientOptions())
+	return a.httpClient, err
+}
+
+func (a *API) clientOptions() gh.ClientOptions {
+	opts := gh.ClientOptions{Host: a.host}
+	if os.Getenv("LOG_LEVEL") == "debug" {
 		logger := NewHTTPLogger(0)
 		opts.Log = &logger
 		opts.LogVerboseHTTP = true
 		opts.LogColorize = true
 	}
+	return opts
+}
 
-	a.httpClient, err = gh.NewHTTPClient(opts)
-	return a.httpClient, err
+// doREST sends a request to the REST API at path (relative to the API base URL)
+// and decodes the JSON response into res, if res is not nil.
+func (a *API) doREST(method string, path string, body io.Reader, res any) error {
+	c, err := a.getHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/%s", a.url, path), body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s: %s %s", method, path, resp.Status, string(b))
+	}
+
+	if res == nil || len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, res)
 }
 
 func (a *API) FetchPRCheckRuns(
@@ -305,7 +365,7 @@ func (a *API) FetchPRCheckRuns(
 		return res, err
 	}
 
-	parsedUrl, err := url.Parse(fmt.Sprintf("https://github.com/%s/pull/%s", repo, prNumber))
+	parsedUrl, err := url.Parse(fmt.Sprintf("%s/%s/pull/%s", a.webURL, repo, prNumber))
 	if err != nil {
 		return res, err
 	}
@@ -324,9 +384,15 @@ func (a *API) FetchPRCheckRuns(
 	return res, nil
 }
 
+// RepoWorkflowRunsPerPage is the number of runs fetched with each page in repo mode
+const RepoWorkflowRunsPerPage = 30
+
+// FetchRepoWorkflowRuns fetches a page of the repo's workflow runs matching the filters,
+// newest first. Pages start at 1.
 func (a *API) FetchRepoWorkflowRuns(
 	repo string,
-	cursor string,
+	filters RepoWorkflowRunsFilters,
+	page int,
 ) (RepoWorkflowRunsResponse, error) {
 	var err error
 	res := RepoWorkflowRunsResponse{}
@@ -335,10 +401,14 @@ func (a *API) FetchRepoWorkflowRuns(
 		return res, err
 	}
 
-	parsedUrl, err := url.Parse(fmt.Sprintf("%s/repos/%s/actions/runs", a.url, repo))
+	parsedUrl, err := url.Parse(fmt.Sprintf("%s/%s", a.url, filters.runsPath(repo)))
 	if err != nil {
 		return res, err
 	}
+	query := filters.query()
+	query.Set("page", strconv.Itoa(max(1, page)))
+	query.Set("per_page", strconv.Itoa(RepoWorkflowRunsPerPage))
+	parsedUrl.RawQuery = query.Encode()
 
 	log.Debug("fetching repo action runs", "url", parsedUrl)
 	startTime := time.Now()
@@ -371,6 +441,8 @@ func (a *API) FetchRepoWorkflowRuns(
 	}
 	log.Debug(
 		"FetchRepoActionRuns data",
+		"page",
+		page,
 		"TotalCount",
 		res.TotalCount,
 		"fetched",
@@ -392,13 +464,29 @@ type WorkflowRunStepsQuery struct {
 					Name string
 				}
 				CheckRuns struct {
-					Nodes []CheckRunWithSteps
-				} `graphql:"checkRuns(first: 100)"`
+					Nodes    []CheckRunWithSteps
+					PageInfo PageInfo
+				} `graphql:"checkRuns(first: 100, after: $cursor)"`
 			}
 		} `graphql:"... on WorkflowRun"`
 	} `graphql:"resource(url: $url)"`
 }
 
+// CheckRunStepsQuery fetches the steps of a single check run that didn't fit
+// in the first page of CheckRunWithSteps
+type CheckRunStepsQuery struct {
+	Node struct {
+		CheckRun struct {
+			Steps struct {
+				Nodes    []Step
+				PageInfo PageInfo
+			} `graphql:"steps(first: 100, after: $cursor)"`
+		} `graphql:"... on CheckRun"`
+	} `graphql:"node(id: $id)"`
+}
+
+// FetchWorkflowRunSteps fetches all check runs of the workflow run with all of their steps,
+// following every page of both.
 func (a *API) FetchWorkflowRunSteps(repo string, runID string) (WorkflowRunStepsQuery, error) {
 	res := WorkflowRunStepsQuery{}
 	c, err := a.getGraphQLClient()
@@ -406,26 +494,89 @@ func (a *API) FetchWorkflowRunSteps(repo string, runID string) (WorkflowRunSteps
 		return res, err
 	}
 
-	runUrl, err := url.Parse(fmt.Sprintf("https://github.com/%s/actions/runs/%s", repo, runID))
+	runUrl, err := url.Parse(fmt.Sprintf("%s/%s/actions/runs/%s", a.webURL, repo, runID))
 	if err != nil {
 		return res, err
 	}
-	variables := map[string]any{
-		"url": githubv4.URI{URL: runUrl},
-	}
 
 	log.Debug("fetching check run steps", "url", runUrl)
 	startTime := time.Now()
-	err = c.Query("FetchCheckRunSteps", &res, variables)
-	if err != nil {
-		log.Error("error fetching check run steps", "err", err)
-		return res, err
+	checkRuns := make([]CheckRunWithSteps, 0)
+	cursor := ""
+	for {
+		page := WorkflowRunStepsQuery{}
+		variables := map[string]any{
+			"url":    githubv4.URI{URL: runUrl},
+			"cursor": githubv4.String(cursor),
+		}
+		err = c.Query("FetchCheckRunSteps", &page, variables)
+		if err != nil {
+			log.Error("error fetching check run steps", "err", err)
+			return res, err
+		}
+
+		res = page
+		checkRuns = append(checkRuns, page.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes...)
+		pageInfo := page.Resource.WorkflowRun.CheckSuite.CheckRuns.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		cursor = pageInfo.EndCursor
+	}
+
+	for i := range checkRuns {
+		pageInfo := checkRuns[i].Steps.PageInfo
+		if !pageInfo.HasNextPage {
+			continue
+		}
+		steps, err := a.fetchRemainingCheckRunSteps(checkRuns[i].Id, pageInfo.EndCursor)
+		if err != nil {
+			return res, err
+		}
+		checkRuns[i].Steps.Nodes = append(checkRuns[i].Steps.Nodes, steps...)
+		checkRuns[i].Steps.PageInfo = PageInfo{}
 	}
+	res.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes = checkRuns
+	res.Resource.WorkflowRun.CheckSuite.CheckRuns.PageInfo = PageInfo{}
 
-	log.Debug("FetchWorkflowRunSteps request completed", "duration", time.Since(startTime))
+	log.Debug(
+		"FetchWorkflowRunSteps request completed",
+		"duration",
+		time.Since(startTime),
+		"checkRuns",
+		len(checkRuns),
+	)
 	return res, nil
 }
 
+func (a *API) fetchRemainingCheckRunSteps(checkRunId string, cursor string) ([]Step, error) {
+	c, err := a.getGraphQLClient()
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]Step, 0)
+	for {
+		page := CheckRunStepsQuery{}
+		variables := map[string]any{
+			"id":     githubv4.ID(checkRunId),
+			"cursor": githubv4.String(cursor),
+		}
+		err = c.Query("FetchCheckRunStepsPage", &page, variables)
+		if err != nil {
+			log.Error("error fetching check run steps page", "checkRunId", checkRunId, "err", err)
+			return nil, err
+		}
+
+		steps = append(steps, page.Node.CheckRun.Steps.Nodes...)
+		pageInfo := page.Node.CheckRun.Steps.PageInfo
+		if !pageInfo.HasNextPage {
+			return steps, nil
+		}
+		cursor = pageInfo.EndCursor
+	}
+}
+
 type httpStep struct {
 	Conclusion  string
 	Name        string
@@ -457,7 +608,7 @@ func (a *API) FetchJobSteps(repo string, jobID string) (NormalizedJobStepsRespon
 	}
 
 	jobUrl, err := url.Parse(
-		fmt.Sprintf("https://api.github.com/repos/%s/actions/jobs/%s", repo, jobID),
+		fmt.Sprintf("%s/repos/%s/actions/jobs/%s", a.url, repo, jobID),
 	)
 	if err != nil {
 		return res, err
@@ -516,15 +667,11 @@ type CheckRunOutput struct {
 	Description string
 }
 
-func FetchCheckRunOutput(repo string, runID string) (CheckRunOutputResponse, error) {
-	client, err := gh.DefaultRESTClient()
+func (a *API) FetchCheckRunOutput(repo string, runID string) (CheckRunOutputResponse, error) {
 	res := CheckRunOutputResponse{}
-	if err != nil {
-		return res, err
-	}
 
 	startTime := time.Now()
-	err = client.Get(fmt.Sprintf("repos/%s/check-runs/%s", repo, runID), &res)
+	err := a.doREST(http.MethodGet, fmt.Sprintf("repos/%s/check-runs/%s", repo, runID), nil, &res)
 	if err != nil {
 		return res, err
 	}
@@ -547,17 +694,22 @@ func (pr *PRWithChecks) IsStatusCheckInProgress() bool {
 		pr.Commits.Nodes[0].Commit.StatusCheckRollup.State == "PENDING" || stats.InProgress > 0)
 }
 
-func ReRunJob(repo string, jobId string) error {
-	client, err := gh.DefaultRESTClient()
-	if err != nil {
-		return err
+// rerunBody is the body of the rerun endpoints, which can turn on the runner's
+// debug logging for the new attempt.
+func rerunBody(debug bool) io.Reader {
+	if !debug {
+		return nil
 	}
+	return strings.NewReader(`{"enable_debug_logging":true}`)
+}
 
-	body := strings.NewReader("")
-	res := struct{}{}
-
-	err = client.Post(fmt.Sprintf("repos/%s/actions/jobs/%s/rerun", repo, jobId), body, res)
-	return err
+func (a *API) ReRunJob(repo string, jobId string, debug bool) error {
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/jobs/%s/rerun", repo, jobId),
+		rerunBody(debug),
+		nil,
+	)
 }
 
 // REST API response for GET /repos/{owner}/{repo}/actions/runs/{run_id}
@@ -607,6 +759,14 @@ type WorkflowRunJob struct {
 	Steps       []httpStep `json:"steps"`
 }
 
+// FetchJob fetches a single job of a workflow run. Jobs are check runs, so this
+// also resolves the run of a check run.
+func (a *API) FetchJob(repo string, jobId string) (WorkflowRunJob, error) {
+	res := WorkflowRunJob{}
+	err := a.doREST(http.MethodGet, fmt.Sprintf("repos/%s/actions/jobs/%s", repo, jobId), nil, &res)
+	return res, err
+}
+
 func (a *API) FetchWorkflowRunByID(repo string, runID string) (WorkflowRunResponse, error) {
 	res := WorkflowRunResponse{}
 	c, err := a.getHTTPClient()
@@ -615,7 +775,7 @@ func (a *API) FetchWorkflowRunByID(repo string, runID string) (WorkflowRunRespon
 	}
 
 	runUrl, err := url.Parse(
-		fmt.Sprintf("https://api.github.com/repos/%s/actions/runs/%s", repo, runID),
+		fmt.Sprintf("%s/repos/%s/actions/runs/%s", a.url, repo, runID),
 	)
 	if err != nil {
 		return res, err
@@ -654,7 +814,62 @@ func (a *API) FetchWorkflowRunByID(repo string, runID string) (WorkflowRunRespon
 	return res, nil
 }
 
+// workflowRunJobsPerPage is the max page size of the workflow run jobs endpoint
+const workflowRunJobsPerPage = 100
+
+// FetchWorkflowRunJobs fetches all jobs of the workflow run, following every page.
 func (a *API) FetchWorkflowRunJobs(repo string, runID string) (WorkflowRunJobsResponse, error) {
+	return a.fetchWorkflowRunJobs(repo, fmt.Sprintf("runs/%s/jobs", runID))
+}
+
+// FetchWorkflowRunAttempt fetches a run as it was in one of its attempts, the
+// fetched run's RunAttempt is the attempt rather than the latest one.
+func (a *API) FetchWorkflowRunAttempt(
+	repo string,
+	runID string,
+	attempt int,
+) (WorkflowRunResponse, error) {
+	res := WorkflowRunResponse{}
+	err := a.doREST(
+		http.MethodGet,
+		fmt.Sprintf("repos/%s/actions/runs/%s/attempts/%d", repo, runID, attempt),
+		nil,
+		&res,
+	)
+	return res, err
+}
+
+// FetchWorkflowRunAttemptJobs fetches the jobs that ran in an attempt of the workflow run
+func (a *API) FetchWorkflowRunAttemptJobs(
+	repo string,
+	runID string,
+	attempt int,
+) (WorkflowRunJobsResponse, error) {
+	return a.fetchWorkflowRunJobs(repo, fmt.Sprintf("runs/%s/attempts/%d/jobs", runID, attempt))
+}
+
+// fetchWorkflowRunJobs fetches all jobs of a jobs path under actions/, following every page.
+func (a *API) fetchWorkflowRunJobs(repo string, jobsPath string) (WorkflowRunJobsResponse, error) {
+	res := WorkflowRunJobsResponse{}
+	for page := 1; ; page++ {
+		pageRes, err := a.fetchWorkflowRunJobsPage(repo, jobsPath, page)
+		if err != nil {
+			return res, err
+		}
+
+		res.TotalCount = pageRes.TotalCount
+		res.Jobs = append(res.Jobs, pageRes.Jobs...)
+		if len(pageRes.Jobs) == 0 || len(res.Jobs) >= res.TotalCount {
+			return res, nil
+		}
+	}
+}
+
+func (a *API) fetchWorkflowRunJobsPage(
+	repo string,
+	jobsPath string,
+	page int,
+) (WorkflowRunJobsResponse, error) {
 	res := WorkflowRunJobsResponse{}
 	c, err := a.getHTTPClient()
 	if err != nil {
@@ -662,11 +877,15 @@ func (a *API) FetchWorkflowRunJobs(repo string, runID string) (WorkflowRunJobsRe
 	}
 
 	jobsUrl, err := url.Parse(
-		fmt.Sprintf("https://api.github.com/repos/%s/actions/runs/%s/jobs", repo, runID),
+		fmt.Sprintf("%s/repos/%s/actions/%s", a.url, repo, jobsPath),
 	)
 	if err != nil {
 		return res, err
 	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(workflowRunJobsPerPage))
+	jobsUrl.RawQuery = query.Encode()
 
 	log.Debug("fetching workflow run jobs", "url", jobsUrl)
 	startTime := time.Now()
@@ -680,8 +899,8 @@ func (a *API) FetchWorkflowRunJobs(repo string, runID string) (WorkflowRunJobsRe
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return res, fmt.Errorf(
-			"failed to fetch workflow run jobs for run %s: %s %s",
-			runID,
+			"failed to fetch workflow run jobs of %s: %s %s",
+			jobsPath,
 			resp.Status,
 			string(body),
 		)
@@ -701,17 +920,43 @@ func (a *API) FetchWorkflowRunJobs(repo string, runID string) (WorkflowRunJobsRe
 	return res, nil
 }
 
-func ReRunRun(repo string, runId string) error {
-	client, err := gh.DefaultRESTClient()
-	if err != nil {
-		return err
-	}
+func (a *API) ReRunRun(repo string, runId string, debug bool) error {
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/runs/%s/rerun", repo, runId),
+		rerunBody(debug),
+		nil,
+	)
+}
 
-	body := strings.NewReader("")
-	res := struct{}{}
+// ReRunFailedJobs reruns the failed and canceled jobs of a run, along with the jobs depending on them.
+func (a *API) ReRunFailedJobs(repo string, runId string, debug bool) error {
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/runs/%s/rerun-failed-jobs", repo, runId),
+		rerunBody(debug),
+		nil,
+	)
+}
 
-	err = client.Post(fmt.Sprintf("repos/%s/actions/runs/%s/rerun", repo, runId), body, res)
-	return err
+func (a *API) CancelRun(repo string, runId string) error {
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/runs/%s/cancel", repo, runId),
+		nil,
+		nil,
+	)
+}
+
+// ForceCancelRun cancels a run bypassing conditions like always() that
+// would keep its jobs running after a regular cancel.
+func (a *API) ForceCancelRun(repo string, runId string) error {
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/runs/%s/force-cancel", repo, runId),
+		nil,
+		nil,
+	)
 }
 
 type PR struct {
@@ -750,7 +995,7 @@ func (a *API) FetchPR(repo string, prNumber string) (PRQuery, error) {
 		return res, err
 	}
 
-	parsedUrl, err := url.Parse(fmt.Sprintf("https://github.com/%s/pull/%s", repo, prNumber))
+	parsedUrl, err := url.Parse(fmt.Sprintf("%s/%s/pull/%s", a.webURL, repo, prNumber))
 	if err != nil {
 		return res, err
 	}
@@ -1,11 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+
+	gh "github.com/cli/go-gh/v2/pkg/api"
 )
 
 func createFakeListRepoActionRunsServer(t *testing.T) *httptest.Server {
@@ -14,6 +24,9 @@ func createFakeListRepoActionRunsServer(t *testing.T) *httptest.Server {
 		if r.URL.Path != "/repos/some/repo/actions/runs" {
 			t.Fatalf("Incorrect path %s", r.URL.Path)
 		}
+		if r.URL.Query().Get("page") != "2" || r.URL.Query().Get("per_page") != "30" {
+			t.Fatalf("Incorrect pagination query %s", r.URL.RawQuery)
+		}
 		d, err := os.ReadFile("./testdata/repoPRs.json")
 		if err != nil {
 			t.Errorf("failed reading mock data file %v", err)
@@ -33,7 +46,7 @@ func TestFetchRepoPRs(t *testing.T) {
 		httpClient: &http.Client{},
 	}
 
-	res, err := api.FetchRepoWorkflowRuns("some/repo", "cursor")
+	res, err := api.FetchRepoWorkflowRuns("some/repo", RepoWorkflowRunsFilters{}, 2)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -51,3 +64,754 @@ func TestFetchRepoPRs(t *testing.T) {
 		t.Fatalf("expected to get run id of 30433642, got %d", run.Id)
 	}
 }
+
+func TestFetchRepoWorkflowRunsWithFilters(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/some/repo/actions/workflows/ci.yml/runs" {
+			t.Fatalf("Incorrect path %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		expected := map[string]string{
+			"branch":   "main",
+			"actor":    "dlvhdr",
+			"event":    "push",
+			"status":   "failure",
+			"created":  ">=2025-01-01",
+			"head_sha": "6dcb09b",
+			"page":     "1",
+		}
+		for k, v := range expected {
+			if q.Get(k) != v {
+				t.Errorf("expected query param %s=%s, got %q", k, v, q.Get(k))
+			}
+		}
+		d, err := os.ReadFile("./testdata/repoPRs.json")
+		if err != nil {
+			t.Errorf("failed reading mock data file %v", err)
+		}
+		fmt.Fprint(w, string(d))
+	}))
+	defer svr.Close()
+
+	api := API{
+		url:        svr.URL,
+		httpClient: &http.Client{},
+	}
+
+	filters := RepoWorkflowRunsFilters{
+		Branch:   "main",
+		Actor:    "dlvhdr",
+		Event:    "push",
+		Status:   "failure",
+		Workflow: "ci.yml",
+		Created:  ">=2025-01-01",
+		HeadSHA:  "6dcb09b",
+	}
+	if _, err := api.FetchRepoWorkflowRuns("some/repo", filters, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "workflow:ci.yml branch:main actor:dlvhdr event:push status:failure created:>=2025-01-01 head_sha:6dcb09b"
+	if filters.String() != expected {
+		t.Errorf("expected filters string %q, got %q", expected, filters.String())
+	}
+
+	if err := (RepoWorkflowRunsFilters{Status: "broken"}).Validate(); err == nil {
+		t.Error("expected an invalid status to fail validation")
+	}
+}
+
+const fakeGHESHost = "ghe.example.com"
+
+// hostCheckingTransport sends all requests to a local fake server and fails the test
+// for any request that isn't addressed to the fake GHES host.
+type hostCheckingTransport struct {
+	t      *testing.T
+	target *url.URL
+}
+
+func (rt hostCheckingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != fakeGHESHost {
+		rt.t.Errorf("request escaped the GHES host: %s", req.URL)
+		return nil, fmt.Errorf("unexpected host %s", req.URL.Host)
+	}
+
+	r := req.Clone(req.Context())
+	r.URL.Scheme = rt.target.Scheme
+	r.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func createFakeGHESServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/graphql":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "https://"+fakeGHESHost+"/some/repo/") {
+				t.Errorf("expected graphql resource url to be on %s, got %s", fakeGHESHost, body)
+			}
+			fmt.Fprint(w, `{"data":{}}`)
+		case r.URL.Path == "/api/v3/repos/some/repo/actions/runs":
+			d, err := os.ReadFile("./testdata/repoPRs.json")
+			if err != nil {
+				t.Errorf("failed reading mock data file %v", err)
+			}
+			fmt.Fprint(w, string(d))
+		case r.URL.Path == "/api/v3/repos/some/repo/actions/runs/1/jobs":
+			fmt.Fprint(w, `{"total_count":0,"jobs":[]}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/rerun"):
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasPrefix(r.URL.Path, "/api/v3/repos/some/repo/"):
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newFakeGHESAPI(t *testing.T, svr *httptest.Server) API {
+	t.Helper()
+
+	target, err := url.Parse(svr.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := gh.ClientOptions{
+		Host:         fakeGHESHost,
+		AuthToken:    "fake-token",
+		Transport:    hostCheckingTransport{t: t, target: target},
+		LogIgnoreEnv: true,
+	}
+	a := newAPI(fakeGHESHost, false)
+	a.httpClient, err = gh.NewHTTPClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.gqlClient, err = gh.NewGraphQLClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestGHESRequestsStayOnHost(t *testing.T) {
+	svr := createFakeGHESServer(t)
+	defer svr.Close()
+
+	a := newFakeGHESAPI(t, svr)
+
+	if _, err := a.FetchPR("some/repo", "1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchPRCheckRuns("some/repo", "1", ""); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchWorkflowRunSteps("some/repo", "1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchRepoWorkflowRuns("some/repo", RepoWorkflowRunsFilters{}, 1); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchWorkflowRunByID("some/repo", "1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchWorkflowRunJobs("some/repo", "1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchJobSteps("some/repo", "1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := a.FetchCheckRunOutput("some/repo", "1"); err != nil {
+		t.Error(err)
+	}
+	if err := a.ReRunJob("some/repo", "1", false); err != nil {
+		t.Error(err)
+	}
+	if err := a.ReRunRun("some/repo", "1", false); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFetchWorkflowRunJobsFollowsPages(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/some/repo/actions/runs/1/jobs" {
+			t.Fatalf("Incorrect path %s", r.URL.Path)
+		}
+		d, err := os.ReadFile(fmt.Sprintf("./testdata/runJobsPage%s.json", r.URL.Query().Get("page")))
+		if err != nil {
+			t.Fatalf("failed reading mock data file %v", err)
+		}
+		fmt.Fprint(w, string(d))
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	res, err := a.FetchWorkflowRunJobs("some/repo", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Jobs) != 120 {
+		t.Fatalf("expected to get 120 jobs, got %d", len(res.Jobs))
+	}
+	if res.Jobs[119].Name != "test (shard-120)" {
+		t.Errorf("expected last job to be shard-120, got %s", res.Jobs[119].Name)
+	}
+}
+
+func TestFetchWorkflowRunStepsFollowsPages(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/graphql" {
+			t.Fatalf("Incorrect path %s", r.URL.Path)
+		}
+		req := struct {
+			Variables map[string]any
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		file := ""
+		switch {
+		case req.Variables["id"] == "CR_matrix_001" && req.Variables["cursor"] == "MTAw":
+			file = "checkRunStepsPage2.json"
+		case req.Variables["cursor"] == "":
+			file = "runStepsPage1.json"
+		case req.Variables["cursor"] == "MTAw":
+			file = "runStepsPage2.json"
+		default:
+			t.Fatalf("unexpected variables %v", req.Variables)
+		}
+		d, err := os.ReadFile("./testdata/" + file)
+		if err != nil {
+			t.Fatalf("failed reading mock data file %v", err)
+		}
+		fmt.Fprint(w, string(d))
+	}))
+	defer svr.Close()
+
+	a := newFakeGHESAPI(t, svr)
+	res, err := a.FetchWorkflowRunSteps("some/repo", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkRuns := res.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes
+	if len(checkRuns) != 105 {
+		t.Fatalf("expected to get 105 check runs, got %d", len(checkRuns))
+	}
+	if len(checkRuns[0].Steps.Nodes) != 110 {
+		t.Fatalf("expected first check run to have 110 steps, got %d", len(checkRuns[0].Steps.Nodes))
+	}
+	if res.Resource.WorkflowRun.CheckSuite.Branch.Name != "shard-matrix" {
+		t.Errorf("expected branch to be kept, got %q", res.Resource.WorkflowRun.CheckSuite.Branch.Name)
+	}
+}
+
+func TestFetchJobLogs(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/some/repo/actions/jobs/1/logs":
+			http.Redirect(w, r, "/blob/1.txt", http.StatusFound)
+		case "/blob/1.txt":
+			fmt.Fprint(w, "2025-06-27T14:28:26.1234567Z ##[group]Run actions/checkout@v4\n")
+		case "/repos/some/repo/actions/jobs/2/logs":
+			w.WriteHeader(http.StatusGone)
+		case "/repos/some/repo/actions/jobs/3/logs":
+			w.WriteHeader(http.StatusNotFound)
+		case "/repos/some/repo/actions/jobs/4/logs":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+
+	logs, err := a.FetchJobLogs("some/repo", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs, "actions/checkout@v4") {
+		t.Errorf("expected redirect to be followed, got %q", logs)
+	}
+
+	tests := []struct {
+		jobId string
+		err   error
+	}{
+		{jobId: "2", err: ErrLogsExpired},
+		{jobId: "3", err: ErrLogsNotFound},
+		{jobId: "4", err: ErrLogsForbidden},
+	}
+	for _, tt := range tests {
+		if _, err := a.FetchJobLogs("some/repo", tt.jobId); !errors.Is(err, tt.err) {
+			t.Errorf("job %s: expected error %v, got %v", tt.jobId, tt.err, err)
+		}
+	}
+}
+
+func TestFetchJob(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/some/repo/actions/jobs/57332991075" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": 57332991075, "run_id": 19991547923, "name": "test"}`)
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+
+	job, err := a.FetchJob("some/repo", "57332991075")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.RunId != 19991547923 || job.Name != "test" {
+		t.Errorf("unexpected job %+v", job)
+	}
+
+	if _, err := a.FetchJob("some/repo", "1"); err == nil {
+		t.Error("expected an error for a check run that isn't a job")
+	}
+}
+
+func TestCancelRun(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	if err := a.CancelRun("some/repo", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ForceCancelRun("some/repo", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /repos/some/repo/actions/runs/1/cancel",
+		"POST /repos/some/repo/actions/runs/1/force-cancel",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestReRunFailedJobsWithDebugLogging(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	if err := a.ReRunFailedJobs("some/repo", "1", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReRunFailedJobs("some/repo", "1", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReRunJob("some/repo", "2", true); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /repos/some/repo/actions/runs/1/rerun-failed-jobs",
+		`POST /repos/some/repo/actions/runs/1/rerun-failed-jobs {"enable_debug_logging":true}`,
+		`POST /repos/some/repo/actions/jobs/2/rerun {"enable_debug_logging":true}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestHostURLs(t *testing.T) {
+	tests := []struct {
+		host string
+		rest string
+		web  string
+	}{
+		{host: "github.com", rest: "https://api.github.com", web: "https://github.com"},
+		{host: fakeGHESHost, rest: "https://ghe.example.com/api/v3", web: "https://ghe.example.com"},
+		{host: "octo.ghe.com", rest: "https://api.octo.ghe.com", web: "https://octo.ghe.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := restURL(tt.host); got != tt.rest {
+				t.Errorf("expected rest url %s, got %s", tt.rest, got)
+			}
+			if got := webURL(tt.host); got != tt.web {
+				t.Errorf("expected web url %s, got %s", tt.web, got)
+			}
+		})
+	}
+}
+
+func TestReviewPendingDeployments(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[{
+				"environment": {"id": 161088068, "name": "production"},
+				"wait_timer": 30,
+				"current_user_can_approve": true,
+				"reviewers": [
+					{"type": "User", "reviewer": {"login": "octocat"}},
+					{"type": "Team", "reviewer": {"slug": "release-managers", "name": "Release managers"}}
+				]
+			}]`)
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	deployments, err := a.FetchPendingDeployments("some/repo", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments) != 1 || deployments[0].Environment.Name != "production" ||
+		!deployments[0].CurrentUserCanApprove || len(deployments[0].Reviewers) != 2 ||
+		deployments[0].Reviewers[1].Reviewer.Slug != "release-managers" {
+		t.Fatalf("unexpected deployments %+v", deployments)
+	}
+
+	err = a.ReviewPendingDeployments("some/repo", "1", []int64{161088068}, DeploymentApproved, "ship it")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /repos/some/repo/actions/runs/1/pending_deployments",
+		`POST /repos/some/repo/actions/runs/1/pending_deployments {"environment_ids":[161088068],"state":"approved","comment":"ship it"}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestParseDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []DispatchInput
+		wantErr error
+	}{
+		{
+			name:    "single event",
+			content: "on: workflow_dispatch\n",
+			want:    []DispatchInput{},
+		},
+		{
+			name:    "list of events",
+			content: "on: [push, workflow_dispatch]\n",
+			want:    []DispatchInput{},
+		},
+		{
+			name:    "not dispatchable",
+			content: "on:\n  push:\n    branches: [main]\n",
+			wantErr: ErrNotDispatchable,
+		},
+		{
+			name: "inputs in order",
+			content: `name: Release
+on:
+  push:
+  workflow_dispatch:
+    inputs:
+      version:
+        description: The version to release
+        required: true
+      environment:
+        type: environment
+      dry-run:
+        type: boolean
+        default: false
+      level:
+        type: choice
+        default: patch
+        options: [patch, minor, major]
+`,
+			want: []DispatchInput{
+				{
+					Name:        "version",
+					Description: "The version to release",
+					Required:    true,
+					Type:        DispatchInputString,
+				},
+				{Name: "environment", Type: DispatchInputEnvironment},
+				{Name: "dry-run", Type: DispatchInputBoolean, Default: "false"},
+				{
+					Name:    "level",
+					Type:    DispatchInputChoice,
+					Default: "patch",
+					Options: []string{"patch", "minor", "major"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDispatchInputs([]byte(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDispatchWorkflow(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		if r.URL.Path == "/repos/some/repo/contents/.github/workflows/release.yml" {
+			fmt.Fprintf(w, `{"content": %q}`,
+				base64.StdEncoding.EncodeToString([]byte("on: workflow_dispatch\n")))
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	content, err := a.FetchFileContent("some/repo", ".github/workflows/release.yml", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "on: workflow_dispatch\n" {
+		t.Errorf("unexpected file content %q", content)
+	}
+
+	err = a.DispatchWorkflow("some/repo", 42, "main", map[string]string{"version": "1.2.3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /repos/some/repo/contents/.github/workflows/release.yml?ref=main",
+		`POST /repos/some/repo/actions/workflows/42/dispatches {"ref":"main","inputs":{"version":"1.2.3"}}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestFetchAnnotations(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		fmt.Fprint(w, `[{
+			"path": "internal/api/api.go",
+			"start_line": 12,
+			"end_line": 12,
+			"start_column": 5,
+			"annotation_level": "failure",
+			"title": "golangci-lint",
+			"message": "unused variable"
+		}]`)
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	annotations, err := a.FetchAnnotations("some/repo", "11")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Annotation{
+		Path:        "internal/api/api.go",
+		StartLine:   12,
+		EndLine:     12,
+		StartColumn: 5,
+		Level:       AnnotationFailure,
+		Title:       "golangci-lint",
+		Message:     "unused variable",
+	}
+	if len(annotations) != 1 || annotations[0] != want {
+		t.Errorf("unexpected annotations %+v", annotations)
+	}
+	if len(requests) != 1 || requests[0] != "/repos/some/repo/check-runs/11/annotations?per_page=100" {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/repos/some/repo/actions/runs/1/artifacts":
+			fmt.Fprint(w, `{"total_count": 1, "artifacts": [{
+				"id": 42,
+				"name": "coverage",
+				"size_in_bytes": 1234,
+				"expired": false,
+				"expires_at": "2025-09-01T10:00:00Z"
+			}]}`)
+		case "/repos/some/repo/actions/artifacts/42/zip":
+			fmt.Fprint(w, "zip archive")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	artifacts, err := a.FetchArtifacts("some/repo", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 1 || artifacts[0].Id != 42 || artifacts[0].Name != "coverage" ||
+		artifacts[0].SizeInBytes != 1234 || artifacts[0].ExpiresAt.IsZero() {
+		t.Fatalf("unexpected artifacts %+v", artifacts)
+	}
+
+	buf := bytes.Buffer{}
+	if err := a.DownloadArtifact("some/repo", 42, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "zip archive" {
+		t.Errorf("unexpected archive %q", buf.String())
+	}
+
+	if err := a.DownloadArtifact("some/repo", 7, &buf); err == nil {
+		t.Error("expected downloading a missing artifact to fail")
+	}
+}
+
+func TestFetchJobSummary(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/some/repo/actions/runs/1/jobs/11/summary_raw":
+			fmt.Fprint(w, "## Test results\n\n| passed | failed |\n| --- | --- |\n| 12 | 0 |\n")
+		case "/some/repo/actions/runs/1/jobs/12/summary_raw":
+			w.WriteHeader(http.StatusNotFound)
+		case "/some/repo/actions/runs/1/jobs/14/summary_raw":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, "<!DOCTYPE html><html><title>Sign in to GitHub</title></html>")
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, webURL: svr.URL, httpClient: &http.Client{}}
+	summary, err := a.FetchJobSummary("some/repo", "1", "11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(summary, "## Test results") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+
+	_, err = a.FetchJobSummary("some/repo", "1", "12")
+	if !errors.Is(err, ErrSummaryUnavailable) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a missing summary to be unavailable with its status, got %v", err)
+	}
+
+	if _, err := a.FetchJobSummary("some/repo", "1", "13"); !errors.Is(err, ErrSummaryUnavailable) {
+		t.Errorf("expected server errors to be returned as unavailable, got %v", err)
+	}
+
+	if _, err := a.FetchJobSummary("some/repo", "1", "14"); !errors.Is(err, ErrSummaryUnavailable) {
+		t.Errorf("expected a sign-in page to be returned as unavailable, got %v", err)
+	}
+}
+
+func TestFetchWorkflowRunAttempt(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/some/repo/actions/runs/1/attempts/1":
+			fmt.Fprint(w, `{"id": 1, "run_attempt": 1, "conclusion": "failure"}`)
+		case "/repos/some/repo/actions/runs/1/attempts/1/jobs":
+			fmt.Fprint(w, `{"total_count": 1, "jobs": [{"id": 11, "name": "test", "run_attempt": 1, "conclusion": "failure"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	run, err := a.FetchWorkflowRunAttempt("some/repo", "1", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.RunAttempt != 1 || run.Conclusion != "failure" {
+		t.Errorf("unexpected run attempt %+v", run)
+	}
+
+	jobs, err := a.FetchWorkflowRunAttemptJobs("some/repo", "1", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Jobs) != 1 || jobs.Jobs[0].Id != 11 || jobs.Jobs[0].RunAttempt != 1 {
+		t.Errorf("unexpected run attempt jobs %+v", jobs)
+	}
+
+	if _, err := a.FetchWorkflowRunAttemptJobs("some/repo", "1", 2); err == nil {
+		t.Error("expected an error for a missing attempt")
+	}
+}
+
+func TestFetchWorkflowsFollowsPages(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/some/repo/actions/workflows" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"total_count": 2, "workflows": [{"id": 1, "name": "CI", "state": "active"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"total_count": 2, "workflows": [{"id": 2, "name": "Release", "state": "active"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer svr.Close()
+
+	a := API{url: svr.URL, httpClient: &http.Client{}}
+	workflows, err := a.FetchWorkflows("some/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workflows) != 2 || workflows[0].Name != "CI" || workflows[1].Name != "Release" {
+		t.Errorf("expected the workflows of both pages, got %+v", workflows)
+	}
+}
+
+func TestAPIURLOverride(t *testing.T) {
+	t.Setenv("GITHUB_API_URL", "https://api.actions.example.com/")
+
+	if a := newAPI("github.com", true); a.url != "https://api.actions.example.com" {
+		t.Errorf("expected GITHUB_API_URL to be used for a resolved host, got %s", a.url)
+	}
+	if a := newAPI(fakeGHESHost, false); a.url != "https://ghe.example.com/api/v3" {
+		t.Errorf("expected GITHUB_API_URL to be ignored for a passed host, got %s", a.url)
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Artifact is a zip archive a workflow run uploaded, e.g. test reports or coverage
+// https://docs.github.com/en/rest/actions/artifacts#list-workflow-run-artifacts
+type Artifact struct {
+	Id          int64     `json:"id"`
+	Name        string    `json:"name"`
+	SizeInBytes int64     `json:"size_in_bytes"`
+	Expired     bool      `json:"expired"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (a *API) FetchArtifacts(repo string, runId string) ([]Artifact, error) {
+	res := struct {
+		Artifacts []Artifact `json:"artifacts"`
+	}{}
+	err := a.doREST(
+		http.MethodGet,
+		fmt.Sprintf("repos/%s/actions/runs/%s/artifacts?per_page=100", repo, runId),
+		nil,
+		&res,
+	)
+	return res.Artifacts, err
+}
+
+// DownloadArtifact writes the zip archive of an artifact to w
+func (a *API) DownloadArtifact(repo string, artifactId int64, w io.Writer) error {
+	c, err := a.getHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Get(fmt.Sprintf("%s/repos/%s/actions/artifacts/%d/zip", a.url, repo, artifactId))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to download artifact %d: %s %s", artifactId, resp.Status, string(body))
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
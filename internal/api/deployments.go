@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PendingDeployment is an environment a workflow run is waiting on to be reviewed.
+// REST API response for GET /repos/{owner}/{repo}/actions/runs/{run_id}/pending_deployments
+// https://docs.github.com/en/rest/actions/workflow-runs#get-pending-deployments-for-a-workflow-run
+type PendingDeployment struct {
+	Environment struct {
+		Id      int64  `json:"id"`
+		Name    string `json:"name"`
+		HtmlUrl string `json:"html_url"`
+	} `json:"environment"`
+	WaitTimer             int                  `json:"wait_timer"` // in minutes
+	WaitTimerStartedAt    time.Time            `json:"wait_timer_started_at"`
+	CurrentUserCanApprove bool                 `json:"current_user_can_approve"`
+	Reviewers             []DeploymentReviewer `json:"reviewers"`
+}
+
+// DeploymentReviewer is a user or a team required to review a deployment
+type DeploymentReviewer struct {
+	Type     string `json:"type"` // User or Team
+	Reviewer struct {
+		Login string `json:"login"`
+		Slug  string `json:"slug"`
+		Name  string `json:"name"`
+	} `json:"reviewer"`
+}
+
+type DeploymentReviewState string
+
+const (
+	DeploymentApproved DeploymentReviewState = "approved"
+	DeploymentRejected DeploymentReviewState = "rejected"
+)
+
+func (a *API) FetchPendingDeployments(repo string, runId string) ([]PendingDeployment, error) {
+	res := make([]PendingDeployment, 0)
+	err := a.doREST(
+		http.MethodGet,
+		fmt.Sprintf("repos/%s/actions/runs/%s/pending_deployments", repo, runId),
+		nil,
+		&res,
+	)
+	return res, err
+}
+
+// ReviewPendingDeployments approves or rejects the deployments of a run to the given
+// environments. Only reviewers of an environment are allowed to do so.
+func (a *API) ReviewPendingDeployments(
+	repo string,
+	runId string,
+	environmentIds []int64,
+	state DeploymentReviewState,
+	comment string,
+) error {
+	body, err := json.Marshal(struct {
+		EnvironmentIds []int64               `json:"environment_ids"`
+		State          DeploymentReviewState `json:"state"`
+		Comment        string                `json:"comment"`
+	}{EnvironmentIds: environmentIds, State: state, Comment: comment})
+	if err != nil {
+		return err
+	}
+
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/runs/%s/pending_deployments", repo, runId),
+		bytes.NewReader(body),
+		nil,
+	)
+}
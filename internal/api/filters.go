@@ -0,0 +1,103 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// RunStatuses are the values accepted by the status filter of the runs endpoint
+var RunStatuses = []string{
+	"completed",
+	"action_required",
+	"cancelled",
+	"failure",
+	"neutral",
+	"skipped",
+	"stale",
+	"success",
+	"timed_out",
+	"in_progress",
+	"queued",
+	"requested",
+	"waiting",
+	"pending",
+}
+
+// RepoWorkflowRunsFilters narrows down the runs listed in repo mode.
+// Empty fields are ignored.
+type RepoWorkflowRunsFilters struct {
+	Branch   string
+	Actor    string
+	Event    string
+	Status   string
+	Workflow string // a workflow ID or file name, e.g. ci.yml
+	Created  string // a date or a range, e.g. >=2025-01-01 or 2025-01-01..2025-01-31
+	HeadSHA  string // the commit the runs were triggered for
+}
+
+func (f RepoWorkflowRunsFilters) IsEmpty() bool {
+	return f == RepoWorkflowRunsFilters{}
+}
+
+func (f RepoWorkflowRunsFilters) Validate() error {
+	if f.Status != "" && !slices.Contains(RunStatuses, f.Status) {
+		return fmt.Errorf(
+			"invalid status %q, expected one of: %s",
+			f.Status,
+			strings.Join(RunStatuses, ", "),
+		)
+	}
+	return nil
+}
+
+// String returns the active filters in a search-like syntax, e.g. "branch:main status:failure"
+func (f RepoWorkflowRunsFilters) String() string {
+	parts := make([]string, 0)
+	for _, p := range f.pairs() {
+		parts = append(parts, p[0]+":"+p[1])
+	}
+	return strings.Join(parts, " ")
+}
+
+// pairs returns the non-empty filters as name/value pairs
+func (f RepoWorkflowRunsFilters) pairs() [][2]string {
+	pairs := make([][2]string, 0)
+	for _, p := range [][2]string{
+		{"workflow", f.Workflow},
+		{"branch", f.Branch},
+		{"actor", f.Actor},
+		{"event", f.Event},
+		{"status", f.Status},
+		{"created", f.Created},
+		{"head_sha", f.HeadSHA},
+	} {
+		if p[1] != "" {
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
+
+// query returns the filters as the query of the runs endpoint, without the workflow
+// which is part of the path rather than the query
+func (f RepoWorkflowRunsFilters) query() url.Values {
+	query := url.Values{}
+	for _, p := range f.pairs() {
+		if p[0] == "workflow" {
+			continue
+		}
+		query.Set(p[0], p[1])
+	}
+	return query
+}
+
+// runsPath returns the REST path listing the repo's runs, scoped to a single
+// workflow when one is set
+func (f RepoWorkflowRunsFilters) runsPath(repo string) string {
+	if f.Workflow != "" {
+		return fmt.Sprintf("repos/%s/actions/workflows/%s/runs", repo, url.PathEscape(f.Workflow))
+	}
+	return fmt.Sprintf("repos/%s/actions/runs", repo)
+}
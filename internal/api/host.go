@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+const DefaultHost = "github.com"
+
+// ResolveHost returns the host to talk to when none was passed explicitly.
+// It respects GH_HOST and the hosts configured in gh, falling back to github.com.
+func ResolveHost(host string) string {
+	if host != "" {
+		return host
+	}
+	h, _ := auth.DefaultHost()
+	if h == "" {
+		return DefaultHost
+	}
+	return h
+}
+
+// restURL returns the base URL of the REST API of the given host, without a trailing slash.
+// E.g. https://api.github.com for github.com and https://HOST/api/v3 for GHES instances.
+func restURL(host string) string {
+	if auth.IsEnterprise(host) {
+		return fmt.Sprintf("https://%s/api/v3", host)
+	}
+	return fmt.Sprintf("https://api.%s", auth.NormalizeHostname(host))
+}
+
+// webURL returns the base URL of the web UI of the given host, without a trailing slash.
+func webURL(host string) string {
+	return fmt.Sprintf("https://%s", strings.ToLower(host))
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"charm.land/log/v2"
+)
+
+var (
+	// ErrLogsExpired is returned when the job's logs are past the repo's retention period
+	ErrLogsExpired = errors.New("the logs for this job have expired")
+
+	// ErrLogsNotFound is returned when GitHub has no logs for the job. It doesn't tell a
+	// job that doesn't exist apart from one whose logs weren't archived yet.
+	ErrLogsNotFound = errors.New("no logs were found for this job")
+
+	// ErrLogsInProgress wraps ErrLogsNotFound for a job that's still running,
+	// as its logs are only archived once it completes
+	ErrLogsInProgress = errors.New("the logs for this job are not available while it is in progress")
+
+	// ErrLogsForbidden is returned when the token isn't allowed to read the repo's actions logs
+	ErrLogsForbidden = errors.New("not allowed to read the logs for this job")
+
+	// ErrSummaryUnavailable is returned when the web UI's route didn't return the job's summary
+	ErrSummaryUnavailable = errors.New("summary unavailable")
+)
+
+// FetchJobLogs fetches the plain text logs of a GitHub Actions job.
+// The endpoint redirects to a short-lived download URL which the HTTP client follows.
+// GitHub usually only serves the logs once the job finished and returns a 404 until then,
+// so they can't be relied on to stream a running job's output.
+// Known failures are returned wrapping ErrLogsExpired, ErrLogsNotFound or ErrLogsForbidden,
+// callers knowing the job is still running should treat ErrLogsNotFound as ErrLogsInProgress.
+func (a *API) FetchJobLogs(repo string, jobId string) (string, error) {
+	c, err := a.getHTTPClient()
+	if err != nil {
+		return "", err
+	}
+
+	logsUrl := fmt.Sprintf("%s/repos/%s/actions/jobs/%s/logs", a.url, repo, jobId)
+	log.Debug("fetching job logs", "url", logsUrl)
+	startTime := time.Now()
+	resp, err := c.Get(logsUrl)
+	if err != nil {
+		return "", err
+	}
+	log.Debug("FetchJobLogs request completed", "duration", time.Since(startTime))
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return string(body), nil
+	case http.StatusGone:
+		return "", fmt.Errorf("%w: %s", ErrLogsExpired, resp.Status)
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w: %s", ErrLogsNotFound, resp.Status)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("%w: %s %s", ErrLogsForbidden, resp.Status, string(body))
+	default:
+		return "", fmt.Errorf(
+			"failed to fetch logs for job %s: %s %s",
+			jobId,
+			resp.Status,
+			string(body),
+		)
+	}
+}
+
+// FetchJobSummary fetches the markdown a GitHub Actions job wrote to GITHUB_STEP_SUMMARY.
+// The REST API doesn't expose summaries, so this uses the summary_raw route the web UI
+// loads them from. The route isn't a supported API and may change without notice, nor
+// does it tell a job without a summary apart from a failure, so any response but a
+// success is returned wrapping ErrSummaryUnavailable with its status.
+// Web routes authenticate with a browser session rather than the API token, so private
+// repos and hosts requiring sign-in answer with a 404 or the sign-in page instead.
+func (a *API) FetchJobSummary(repo string, runId string, jobId string) (string, error) {
+	c, err := a.getHTTPClient()
+	if err != nil {
+		return "", err
+	}
+
+	summaryUrl := fmt.Sprintf("%s/%s/actions/runs/%s/jobs/%s/summary_raw", a.webURL, repo, runId, jobId)
+	log.Debug("fetching job summary", "url", summaryUrl)
+	resp, err := c.Get(summaryUrl)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK &&
+		strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html"):
+		return "", fmt.Errorf("%w: got a web page instead of the summary", ErrSummaryUnavailable)
+	case resp.StatusCode == http.StatusOK:
+		return string(body), nil
+	case resp.StatusCode == http.StatusNoContent:
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrSummaryUnavailable, resp.Status)
+	}
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotDispatchable is returned when a workflow has no workflow_dispatch trigger
+var ErrNotDispatchable = errors.New("the workflow can't be run manually, it has no workflow_dispatch trigger")
+
+// Workflow is a workflow file of a repo
+// https://docs.github.com/en/rest/actions/workflows#list-repository-workflows
+type Workflow struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	State string `json:"state"` // active, disabled_manually or disabled_inactivity
+}
+
+// workflowsPerPage is the max page size of the workflows and environments endpoints
+const workflowsPerPage = 100
+
+// FetchWorkflows fetches all workflows of a repo, following every page.
+func (a *API) FetchWorkflows(repo string) ([]Workflow, error) {
+	workflows := make([]Workflow, 0)
+	for page := 1; ; page++ {
+		res := struct {
+			TotalCount int        `json:"total_count"`
+			Workflows  []Workflow `json:"workflows"`
+		}{}
+		err := a.doREST(
+			http.MethodGet,
+			fmt.Sprintf("repos/%s/actions/workflows?per_page=%d&page=%d", repo, workflowsPerPage, page),
+			nil,
+			&res,
+		)
+		if err != nil {
+			return workflows, err
+		}
+
+		workflows = append(workflows, res.Workflows...)
+		if len(res.Workflows) == 0 || len(workflows) >= res.TotalCount {
+			return workflows, nil
+		}
+	}
+}
+
+func (a *API) FetchDefaultBranch(repo string) (string, error) {
+	res := struct {
+		DefaultBranch string `json:"default_branch"`
+	}{}
+	err := a.doREST(http.MethodGet, fmt.Sprintf("repos/%s", repo), nil, &res)
+	return res.DefaultBranch, err
+}
+
+// FetchEnvironments fetches the names of the deployment environments of a repo,
+// following every page.
+func (a *API) FetchEnvironments(repo string) ([]string, error) {
+	names := make([]string, 0)
+	for page := 1; ; page++ {
+		res := struct {
+			TotalCount   int `json:"total_count"`
+			Environments []struct {
+				Name string `json:"name"`
+			} `json:"environments"`
+		}{}
+		err := a.doREST(
+			http.MethodGet,
+			fmt.Sprintf("repos/%s/environments?per_page=%d&page=%d", repo, workflowsPerPage, page),
+			nil,
+			&res,
+		)
+		if err != nil {
+			return names, err
+		}
+
+		for _, env := range res.Environments {
+			names = append(names, env.Name)
+		}
+		if len(res.Environments) == 0 || len(names) >= res.TotalCount {
+			return names, nil
+		}
+	}
+}
+
+// FetchFileContent fetches a file of a repo at a ref, or at the default branch when ref is empty
+func (a *API) FetchFileContent(repo string, path string, ref string) ([]byte, error) {
+	res := struct {
+		Content string `json:"content"`
+	}{}
+	p := fmt.Sprintf("repos/%s/contents/%s", repo, path)
+	if ref != "" {
+		p += "?ref=" + url.QueryEscape(ref)
+	}
+	if err := a.doREST(http.MethodGet, p, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(res.Content, "\n", ""))
+}
+
+// DispatchWorkflow triggers a workflow_dispatch run of a workflow on a branch or tag.
+// Inputs that are left out use their default from the workflow file.
+func (a *API) DispatchWorkflow(
+	repo string,
+	workflowId int64,
+	ref string,
+	inputs map[string]string,
+) error {
+	body, err := json.Marshal(struct {
+		Ref    string            `json:"ref"`
+		Inputs map[string]string `json:"inputs,omitempty"`
+	}{Ref: ref, Inputs: inputs})
+	if err != nil {
+		return err
+	}
+
+	return a.doREST(
+		http.MethodPost,
+		fmt.Sprintf("repos/%s/actions/workflows/%d/dispatches", repo, workflowId),
+		bytes.NewReader(body),
+		nil,
+	)
+}
+
+type DispatchInputType string
+
+const (
+	DispatchInputString      DispatchInputType = "string"
+	DispatchInputNumber      DispatchInputType = "number"
+	DispatchInputBoolean     DispatchInputType = "boolean"
+	DispatchInputChoice      DispatchInputType = "choice"
+	DispatchInputEnvironment DispatchInputType = "environment"
+)
+
+// DispatchInput is an input of a workflow's workflow_dispatch trigger
+// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax#onworkflow_dispatchinputs
+type DispatchInput struct {
+	Name        string
+	Description string            `yaml:"description"`
+	Required    bool              `yaml:"required"`
+	Default     string            `yaml:"default"`
+	Type        DispatchInputType `yaml:"type"`
+	Options     []string          `yaml:"options"`
+}
+
+// ParseDispatchInputs parses the inputs of a workflow file's workflow_dispatch trigger,
+// in the order they are defined. ErrNotDispatchable is returned when there is no such trigger.
+func ParseDispatchInputs(content []byte) ([]DispatchInput, error) {
+	var workflow struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, fmt.Errorf("failed parsing the workflow file: %w", err)
+	}
+
+	// the triggers are either a single event, a list of events or a map of events to their config
+	on := workflow.On
+	switch on.Kind {
+	case yaml.ScalarNode:
+		if on.Value == "workflow_dispatch" {
+			return []DispatchInput{}, nil
+		}
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event.Value == "workflow_dispatch" {
+				return []DispatchInput{}, nil
+			}
+		}
+	case yaml.MappingNode:
+		if dispatch := mappingValue(&on, "workflow_dispatch"); dispatch != nil {
+			return parseInputs(mappingValue(dispatch, "inputs"))
+		}
+	}
+	return nil, ErrNotDispatchable
+}
+
+func parseInputs(node *yaml.Node) ([]DispatchInput, error) {
+	inputs := []DispatchInput{}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return inputs, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		input := DispatchInput{}
+		if err := node.Content[i+1].Decode(&input); err != nil {
+			return nil, fmt.Errorf("failed parsing the input %q: %w", node.Content[i].Value, err)
+		}
+		input.Name = node.Content[i].Value
+		if input.Type == "" {
+			input.Type = DispatchInputString
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
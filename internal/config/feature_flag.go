@@ -4,6 +4,10 @@ import "os"
 
 const FF_MOCK_DATA = "FF_MOCK_DATA"
 
+// FF_JOB_SUMMARIES fetches job summaries from a web UI route that isn't part of the API
+// and may not accept API tokens, so they're opt-in
+const FF_JOB_SUMMARIES = "FF_JOB_SUMMARIES"
+
 func IsFeatureEnabled(name string) bool {
 	_, ok := os.LookupEnv(name)
 	return ok
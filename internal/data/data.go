@@ -1,10 +1,15 @@
 package data
 
 import (
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/dlvhdr/gh-enhance/internal/api"
 )
 
@@ -16,11 +21,14 @@ type WorkflowRun struct {
 	DisplayTitle string
 	Link         string
 	Workflow     string
+	WorkflowId   int    // only set for runs fetched through REST
+	HeadSha      string // only set for runs fetched through REST
 	Event        string
 	Jobs         []WorkflowJob
 	Bucket       CheckBucket
 	StartedAt    time.Time
 	RunNumber    int
+	RunAttempt   int // the latest attempt, only set for runs fetched through REST
 	PRNumber     int
 	Status       string
 	Conclusion   string
@@ -33,7 +41,7 @@ type WorkflowJob struct {
 	Name        string
 	Title       string
 	Workflow    string
-	PendingEnv  string
+	PendingEnv  string // the environments waiting for a review, comma separated
 	Event       string
 	Logs        []LogsWithTime
 	Link        string
@@ -45,6 +53,31 @@ type WorkflowJob struct {
 
 	// A number that uniquely identifies this workflow run in its parent workflow.
 	RunNumber int
+
+	// The attempt of the run the job ran in, only set for jobs fetched through REST
+	RunAttempt int
+}
+
+// ExternalStatusesRunId is the id of the pseudo-run grouping the commit statuses
+// reported by external services, e.g. Jenkins or Vercel. It isn't a run on GitHub.
+const ExternalStatusesRunId = "external-statuses"
+
+// IsExternalStatuses reports whether the run is the pseudo-run of the commit statuses
+func (run WorkflowRun) IsExternalStatuses() bool {
+	return run.Id == ExternalStatusesRunId
+}
+
+// IsActionsRun reports whether the run is a GitHub Actions run, the only kind of
+// run that can be canceled, rerun or have deployments and artifacts. Runs fetched
+// through REST always are, while in PR mode the check suites of other apps and the
+// external statuses are grouped into runs too, so their jobs tell them apart.
+func (run WorkflowRun) IsActionsRun() bool {
+	if run.IsExternalStatuses() {
+		return false
+	}
+	return run.WorkflowId != 0 || slices.ContainsFunc(run.Jobs, func(job WorkflowJob) bool {
+		return job.Kind == JobKindGithubActions
+	})
 }
 
 type LogKind int
@@ -58,6 +91,12 @@ const (
 	LogKindError
 	LogKindJobCleanup
 	LogKindCompleteJob
+	LogKindWarning
+	LogKindNotice
+	LogKindDebug
+	LogKindSection
+	LogKindMask         // an ::add-mask:: command, its value is masked
+	LogKindStopCommands // a ::stop-commands:: command or the line resuming them
 )
 
 type LogsWithTime struct {
@@ -65,6 +104,56 @@ type LogsWithTime struct {
 	Time  time.Time
 	Kind  LogKind
 	Depth int
+	// Properties are set for lines written by workflow commands, e.g. ::error file=main.go::
+	Properties LogProperties
+}
+
+// LogProperties are the properties of a workflow command, e.g. the file and line of
+// ::error file=main.go,line=12,title=Build failed::undefined: foo
+// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-commands
+type LogProperties struct {
+	Title     string
+	File      string
+	Line      int
+	EndLine   int
+	Col       int
+	EndColumn int
+}
+
+// Location returns where in a file the command is about, e.g. main.go:12:5
+func (p LogProperties) Location() string {
+	if p.File == "" {
+		return ""
+	}
+	location := p.File
+	if p.Line > 0 {
+		location += ":" + strconv.Itoa(p.Line)
+		if p.Col > 0 {
+			location += ":" + strconv.Itoa(p.Col)
+		}
+	}
+	return location
+}
+
+var (
+	timestampRegex = regexp.MustCompile(
+		`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?|\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`)
+	guidRegex = regexp.MustCompile(
+		`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	// the workspace of hosted runners, containers, Windows runners and self-hosted runners
+	runnerPathRegex = regexp.MustCompile(
+		`(/home/runner/work|/Users/runner/work|/__w|[A-Za-z]:\\a|/[^\s:'"]*?/_work)[/\\][^/\\\s]+[/\\][^/\\\s'"]+`)
+)
+
+// Normalized returns the text of the log line without the parts that differ
+// between runs of the same job, i.e. timestamps, runner paths and GUIDs, so the
+// logs of two jobs can be compared.
+func (l LogsWithTime) Normalized() string {
+	text := ansi.Strip(l.Log)
+	text = timestampRegex.ReplaceAllString(text, "<time>")
+	text = guidRegex.ReplaceAllString(text, "<guid>")
+	text = runnerPathRegex.ReplaceAllString(text, "<workspace>")
+	return strings.TrimRight(text, " \t")
 }
 
 type JobKind int
@@ -73,8 +162,22 @@ const (
 	JobKindCheckRun JobKind = iota
 	JobKindGithubActions
 	JobKindExternal
+	JobKindStatusContext // a commit status, e.g. from Jenkins, not a check run
 )
 
+func (k JobKind) String() string {
+	switch k {
+	case JobKindGithubActions:
+		return "github_actions"
+	case JobKindExternal:
+		return "external"
+	case JobKindStatusContext:
+		return "status_context"
+	default:
+		return "check_run"
+	}
+}
+
 type CheckBucket int
 
 const (
@@ -87,6 +190,26 @@ const (
 	CheckBucketNeutral
 )
 
+// String returns the name of the bucket as used by `gh pr checks`.
+func (b CheckBucket) String() string {
+	switch b {
+	case CheckBucketPass:
+		return "pass"
+	case CheckBucketFail:
+		return "fail"
+	case CheckBucketSkipping:
+		return "skipping"
+	case CheckBucketCancel:
+		return "cancel"
+	case CheckBucketActionRequired:
+		return "action_required"
+	case CheckBucketNeutral:
+		return "neutral"
+	default:
+		return "pending"
+	}
+}
+
 func GetConclusionBucket(conclusion api.Conclusion) CheckBucket {
 	switch conclusion {
 	case "SUCCESS":
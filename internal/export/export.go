@@ -0,0 +1,242 @@
+// Package export prints workflow runs as JSON or through a Go template,
+// following the conventions of gh's --json and --template flags.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// Run is the stable JSON schema of a workflow run and its jobs.
+type Run struct {
+	Id           string    `json:"id"`
+	Name         string    `json:"name"`
+	DisplayTitle string    `json:"displayTitle"`
+	Url          string    `json:"url"`
+	Workflow     string    `json:"workflow"`
+	Event        string    `json:"event"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	Bucket       string    `json:"bucket"`
+	StartedAt    time.Time `json:"startedAt,omitzero"`
+	RunNumber    int       `json:"runNumber"`
+	PRNumber     int       `json:"prNumber"`
+	Jobs         []Job     `json:"jobs"`
+}
+
+type Job struct {
+	Id                 string    `json:"id"`
+	Name               string    `json:"name"`
+	Title              string    `json:"title"`
+	Workflow           string    `json:"workflow"`
+	Event              string    `json:"event"`
+	Kind               string    `json:"kind"`
+	Status             string    `json:"status"`
+	Conclusion         string    `json:"conclusion"`
+	Bucket             string    `json:"bucket"`
+	Url                string    `json:"url"`
+	PendingEnvironment string    `json:"pendingEnvironment"`
+	StartedAt          time.Time `json:"startedAt,omitzero"`
+	CompletedAt        time.Time `json:"completedAt,omitzero"`
+	RunNumber          int       `json:"runNumber"`
+	Steps              []Step    `json:"steps"`
+}
+
+type Step struct {
+	Number      int       `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"startedAt,omitzero"`
+	CompletedAt time.Time `json:"completedAt,omitzero"`
+}
+
+// RunFields are the fields that can be passed to --json.
+var RunFields = []string{
+	"bucket",
+	"conclusion",
+	"displayTitle",
+	"event",
+	"id",
+	"jobs",
+	"name",
+	"prNumber",
+	"runNumber",
+	"startedAt",
+	"status",
+	"url",
+	"workflow",
+}
+
+func FromRuns(runs []data.WorkflowRun) []Run {
+	res := make([]Run, 0, len(runs))
+	for _, run := range runs {
+		jobs := make([]Job, 0, len(run.Jobs))
+		for _, job := range run.Jobs {
+			jobs = append(jobs, fromJob(job))
+		}
+		res = append(res, Run{
+			Id:           run.Id,
+			Name:         run.Name,
+			DisplayTitle: run.DisplayTitle,
+			Url:          run.Link,
+			Workflow:     run.Workflow,
+			Event:        run.Event,
+			Status:       strings.ToLower(run.Status),
+			Conclusion:   strings.ToLower(run.Conclusion),
+			Bucket:       run.Bucket.String(),
+			StartedAt:    run.StartedAt,
+			RunNumber:    run.RunNumber,
+			PRNumber:     run.PRNumber,
+			Jobs:         jobs,
+		})
+	}
+	return res
+}
+
+func fromJob(job data.WorkflowJob) Job {
+	steps := make([]Step, 0, len(job.Steps))
+	for _, step := range job.Steps {
+		steps = append(steps, fromStep(step))
+	}
+	return Job{
+		Id:                 job.Id,
+		Name:               job.Name,
+		Title:              job.Title,
+		Workflow:           job.Workflow,
+		Event:              job.Event,
+		Kind:               job.Kind.String(),
+		Status:             strings.ToLower(string(job.State)),
+		Conclusion:         strings.ToLower(string(job.Conclusion)),
+		Bucket:             job.Bucket.String(),
+		Url:                job.Link,
+		PendingEnvironment: job.PendingEnv,
+		StartedAt:          job.StartedAt,
+		CompletedAt:        job.CompletedAt,
+		RunNumber:          job.RunNumber,
+		Steps:              steps,
+	}
+}
+
+func fromStep(step api.Step) Step {
+	return Step{
+		Number:      step.Number,
+		Name:        step.Name,
+		Status:      strings.ToLower(string(step.Status)),
+		Conclusion:  strings.ToLower(string(step.Conclusion)),
+		StartedAt:   step.StartedAt,
+		CompletedAt: step.CompletedAt,
+	}
+}
+
+// ValidateFields checks the fields passed to --json.
+func ValidateFields(fields []string) error {
+	for _, f := range fields {
+		if !slices.Contains(RunFields, f) {
+			return fmt.Errorf("unknown JSON field: %q\nAvailable fields:\n  %s",
+				f, strings.Join(RunFields, "\n  "))
+		}
+	}
+	return nil
+}
+
+// Print writes the runs as JSON with only the given fields, or with all of them when
+// no fields are given. When tmpl is set the JSON is rendered through it instead.
+func Print(w io.Writer, runs []data.WorkflowRun, fields []string, tmpl string) error {
+	if err := ValidateFields(fields); err != nil {
+		return err
+	}
+
+	selected, err := selectFields(FromRuns(runs), fields)
+	if err != nil {
+		return err
+	}
+
+	if tmpl != "" {
+		return executeTemplate(w, tmpl, selected)
+	}
+
+	b, err := json.MarshalIndent(selected, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
+// selectFields round trips the runs through JSON so templates see the same
+// field names the JSON output has, like gh does.
+func selectFields(runs []Run, fields []string) ([]map[string]any, error) {
+	b, err := json.Marshal(runs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]any, 0, len(runs))
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&res); err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return res, nil
+	}
+	for _, run := range res {
+		for k := range run {
+			if !slices.Contains(fields, k) {
+				delete(run, k)
+			}
+		}
+	}
+	return res, nil
+}
+
+func executeTemplate(w io.Writer, tmpl string, runs []map[string]any) error {
+	t, err := template.New("").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed parsing template: %w", err)
+	}
+	return t.Execute(w, runs)
+}
+
+var templateFuncs = template.FuncMap{
+	"join": func(sep string, items []any) string {
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, sep)
+	},
+	"pluck": func(field string, items []any) []any {
+		res := make([]any, 0, len(items))
+		for _, item := range items {
+			if m, ok := item.(map[string]any); ok {
+				res = append(res, m[field])
+			}
+		}
+		return res
+	},
+	"truncate": func(length int, s string) string {
+		if r := []rune(s); len(r) > length {
+			return string(r[:max(length-1, 0)]) + "…"
+		}
+		return s
+	},
+	"timefmt": func(format string, s string) (string, error) {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(format), nil
+	},
+}
@@ -0,0 +1,159 @@
+package export
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func testRuns() []data.WorkflowRun {
+	started := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	return []data.WorkflowRun{
+		{
+			Id:           "100",
+			Name:         "CI",
+			DisplayTitle: "fix: handle empty logs",
+			Link:         "https://github.com/dlvhdr/gh-enhance/actions/runs/100",
+			Workflow:     "CI",
+			Event:        "pull_request",
+			Bucket:       data.CheckBucketFail,
+			StartedAt:    started,
+			RunNumber:    42,
+			PRNumber:     7,
+			Status:       "completed",
+			Conclusion:   "failure",
+			Jobs: []data.WorkflowJob{
+				{
+					Id:          "1000",
+					State:       api.StatusCompleted,
+					Conclusion:  api.ConclusionFailure,
+					Name:        "test",
+					Workflow:    "CI",
+					Event:       "pull_request",
+					Link:        "https://github.com/dlvhdr/gh-enhance/actions/runs/100/job/1000",
+					StartedAt:   started,
+					CompletedAt: started.Add(2 * time.Minute),
+					Bucket:      data.CheckBucketFail,
+					Kind:        data.JobKindGithubActions,
+					RunNumber:   42,
+					Steps: []api.Step{
+						{
+							Number:      1,
+							Name:        "Set up job",
+							Status:      api.StatusCompleted,
+							Conclusion:  api.ConclusionSuccess,
+							StartedAt:   started,
+							CompletedAt: started.Add(time.Second),
+						},
+						{
+							Number:      2,
+							Name:        "Run go test ./...",
+							Status:      api.StatusCompleted,
+							Conclusion:  api.ConclusionFailure,
+							StartedAt:   started.Add(time.Second),
+							CompletedAt: started.Add(2 * time.Minute),
+						},
+					},
+				},
+				{
+					Id:         "1001",
+					State:      api.StatusQueued,
+					Name:       "deploy",
+					Workflow:   "CI",
+					Event:      "pull_request",
+					PendingEnv: "production",
+					Link:       "https://github.com/dlvhdr/gh-enhance/actions/runs/100/job/1001",
+					Bucket:     data.CheckBucketPending,
+					Kind:       data.JobKindGithubActions,
+					RunNumber:  42,
+				},
+			},
+		},
+		{
+			Id:     "200",
+			Name:   "codecov/patch",
+			Link:   "https://app.codecov.io/gh/dlvhdr/gh-enhance",
+			Bucket: data.CheckBucketPass,
+			Jobs: []data.WorkflowJob{
+				{
+					Id:         "2000",
+					State:      api.StatusCompleted,
+					Conclusion: api.ConclusionSuccess,
+					Name:       "codecov/patch",
+					Title:      "92% of diff hit",
+					Link:       "https://app.codecov.io/gh/dlvhdr/gh-enhance",
+					Bucket:     data.CheckBucketPass,
+					Kind:       data.JobKindCheckRun,
+				},
+			},
+		},
+	}
+}
+
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading golden file %s: %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("output doesn't match %s, run with -update to accept it\ngot:\n%s\nwant:\n%s",
+			path, got, want)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		tmpl   string
+		golden string
+	}{
+		{name: "all fields", golden: "all.golden.json"},
+		{
+			name:   "selected fields",
+			fields: []string{"name", "bucket", "url"},
+			golden: "fields.golden.json",
+		},
+		{
+			name:   "template",
+			fields: []string{"name", "jobs"},
+			tmpl: `{{range .}}{{.name}}: {{join ", " (pluck "name" .jobs)}}
+{{range .jobs}}  {{.bucket}} {{truncate 10 .name}}{{if .completedAt}} {{timefmt "15:04" .completedAt}}{{end}}
+{{end}}{{end}}`,
+			golden: "template.golden.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := strings.Builder{}
+			if err := Print(&out, testRuns(), tt.fields, tt.tmpl); err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.golden, out.String())
+		})
+	}
+}
+
+func TestPrintUnknownField(t *testing.T) {
+	err := Print(&strings.Builder{}, testRuns(), []string{"name", "nope"}, "")
+	if err == nil || !strings.Contains(err.Error(), `unknown JSON field: "nope"`) {
+		t.Errorf("expected an unknown field error, got %v", err)
+	}
+}
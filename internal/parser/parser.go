@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,73 +15,185 @@ const (
 	GroupEndMarker       = "##[endgroup]"
 	CommandMarker        = "[command]"
 	ErrorMarker          = "##[error]"
+	WarningMarker        = "##[warning]"
+	NoticeMarker         = "##[notice]"
+	DebugMarker          = "##[debug]"
+	SectionMarker        = "##[section]"
 	PostJobCleanupMarker = "Post job cleanup."
 	CompleteJobMarker    = "Cleaning up orphan processes"
+
+	// MaskedValue replaces the values masked with ::add-mask::
+	MaskedValue = "***"
 )
 
+// ParseJobLogs parses the logs of a job as returned by the REST API,
+// where each line is prefixed by its timestamp
 func ParseJobLogs(jobLogs string) []data.LogsWithTime {
-	lines := strings.Lines(jobLogs)
+	lines := strings.Lines(strings.TrimPrefix(jobLogs, "\ufeff"))
 	stepsLogs := make([]data.LogsWithTime, 0)
 
 	var lastTime time.Time
 	var err error
-	var name, step string
-	count, depth := 0, 0
+	depth := 0
+	masks := make([]string, 0)
+	// workflow commands aren't processed until the token passed to ::stop-commands:: is written
+	stopToken := ""
 
 	for line := range lines {
-		fields := strings.SplitN(line, string('\t'), 3)
-
-		if count == 0 {
-			name = fields[0]
-			step = fields[1]
-		}
-
-		if name != "" && step != "" {
-			line = strings.Replace(line, name+string('\t'), "", 1)
-			line = strings.Replace(line, step+string('\t'), "", 1)
-		}
-
-		dateAndLog := strings.SplitN(fields[2], " ", 2)
-		var lineDate time.Time
+		dateAndLog := strings.SplitN(line, " ", 2)
+		lineDate := lastTime
+		text := line
 		if len(dateAndLog) == 2 {
 			lineDate, err = time.Parse(time.RFC3339, dateAndLog[0])
 			if err == nil {
 				lastTime = lineDate
+				text = dateAndLog[1]
 			} else {
 				lineDate = lastTime
 			}
-		} else {
-			lineDate = lastTime
+		}
+		text = strings.TrimRight(text, "\r\n")
+		for _, mask := range masks {
+			text = strings.ReplaceAll(text, mask, MaskedValue)
 		}
 
-		text := strings.Join(dateAndLog[1:], "")
 		log := data.LogsWithTime{Time: lineDate}
-		if strings.Contains(line, StepStartMarker) {
+		isCommand := false
+		if stopToken != "" {
+			if strings.TrimSpace(text) == "::"+stopToken+"::" {
+				stopToken = ""
+				log.Kind = data.LogKindStopCommands
+				isCommand = true
+			}
+		} else if cmd, ok := parseWorkflowCommand(text); ok {
+			switch cmd.name {
+			case "error", "warning", "notice":
+				text = "##[" + cmd.name + "]" + cmd.message
+				log.Properties = cmd.properties()
+			case "debug":
+				text = DebugMarker + cmd.message
+			case "group":
+				text = GroupStartMarker + cmd.message
+			case "endgroup":
+				text = GroupEndMarker
+			case "add-mask":
+				if cmd.message != "" {
+					masks = append(masks, cmd.message)
+				}
+				text = "::add-mask::" + MaskedValue
+				log.Kind = data.LogKindMask
+				isCommand = true
+			case "stop-commands":
+				stopToken = cmd.message
+				log.Kind = data.LogKindStopCommands
+				isCommand = true
+			}
+		}
+
+		switch {
+		case isCommand:
+		case strings.Contains(text, StepStartMarker):
 			depth++
 			log.Kind = data.LogKindStepStart
-		} else if strings.Contains(line, GroupStartMarker) {
+		case strings.Contains(text, GroupStartMarker):
 			depth++
 			log.Kind = data.LogKindGroupStart
-		} else if strings.Contains(text, GroupEndMarker) {
+		case strings.Contains(text, GroupEndMarker):
 			depth = max(0, depth-1)
-			text = "\n"
+			text = ""
 			log.Kind = data.LogKindGroupEnd
-		} else if strings.Contains(text, PostJobCleanupMarker) {
+		case strings.Contains(text, PostJobCleanupMarker):
 			log.Kind = data.LogKindJobCleanup
-		} else if strings.Contains(text, CommandMarker) {
+		case strings.Contains(text, CommandMarker):
 			log.Kind = data.LogKindCommand
-		} else if strings.Contains(text, ErrorMarker) {
+		case strings.Contains(text, ErrorMarker):
 			log.Kind = data.LogKindError
+		case strings.Contains(text, WarningMarker):
+			log.Kind = data.LogKindWarning
+		case strings.Contains(text, NoticeMarker):
+			log.Kind = data.LogKindNotice
+		case strings.Contains(text, DebugMarker):
+			log.Kind = data.LogKindDebug
+		case strings.Contains(text, SectionMarker):
+			log.Kind = data.LogKindSection
 		}
 
 		log.Depth = depth
-		log.Log = strings.TrimRight(text, "\n")
+		log.Log = text
 		stepsLogs = append(stepsLogs, log)
 	}
 
 	return stepsLogs
 }
 
+// workflowCommand is a command a step wrote to its output, e.g.
+// ::error file=main.go,line=12::undefined: foo
+type workflowCommand struct {
+	name    string
+	props   map[string]string
+	message string
+}
+
+// parseWorkflowCommand parses a ::name key=value,...::message line
+func parseWorkflowCommand(text string) (workflowCommand, bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "::") {
+		return workflowCommand{}, false
+	}
+	header, message, ok := strings.Cut(text[2:], "::")
+	if !ok || header == "" {
+		return workflowCommand{}, false
+	}
+
+	name, props, _ := strings.Cut(header, " ")
+	cmd := workflowCommand{
+		name:    name,
+		props:   map[string]string{},
+		message: unescapeCommandData(message),
+	}
+	for prop := range strings.SplitSeq(props, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(prop), "=")
+		if ok && k != "" {
+			cmd.props[k] = unescapeCommandProperty(v)
+		}
+	}
+	return cmd, true
+}
+
+func (cmd workflowCommand) properties() data.LogProperties {
+	number := func(keys ...string) int {
+		for _, k := range keys {
+			if n, err := strconv.Atoi(cmd.props[k]); err == nil {
+				return n
+			}
+		}
+		return 0
+	}
+	return data.LogProperties{
+		Title:     cmd.props["title"],
+		File:      cmd.props["file"],
+		Line:      number("line"),
+		EndLine:   number("endLine"),
+		Col:       number("col", "column"),
+		EndColumn: number("endColumn"),
+	}
+}
+
+// the escaping the runner applies to the message and properties of commands
+var (
+	commandDataUnescaper = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%25", "%")
+	commandPropUnescaper = strings.NewReplacer(
+		"%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%")
+)
+
+func unescapeCommandData(s string) string {
+	return commandDataUnescaper.Replace(s)
+}
+
+func unescapeCommandProperty(s string) string {
+	return commandPropUnescaper.Replace(s)
+}
+
 func ParseRunOutputMarkdown(output string, width int) (string, error) {
 	renderer := markdown.GetMarkdownRenderer(width)
 	return renderer.Render(output)
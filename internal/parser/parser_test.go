@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestParseJobLogs(t *testing.T) {
+	type wantLine struct {
+		kind  data.LogKind
+		log   string
+		depth int
+		props data.LogProperties
+	}
+
+	tests := []struct {
+		name string
+		logs string
+		want []wantLine
+	}{
+		{
+			name: "step with a command and an error",
+			logs: "2025-06-01T10:00:01.0000000Z ##[group]Run go test ./...\n" +
+				"2025-06-01T10:00:01.1000000Z \x1b[36;1mgo test ./...\x1b[0m\n" +
+				"2025-06-01T10:00:01.2000000Z ##[endgroup]\n" +
+				"2025-06-01T10:00:03.0000000Z FAIL\tgithub.com/dlvhdr/gh-enhance/internal/tui\t0.123s\n" +
+				"2025-06-01T10:00:03.1000000Z ##[error]Process completed with exit code 1.\n",
+			want: []wantLine{
+				{kind: data.LogKindStepStart, log: "##[group]Run go test ./...", depth: 1},
+				{kind: data.LogKindStepNone, log: "\x1b[36;1mgo test ./...\x1b[0m", depth: 1},
+				{kind: data.LogKindGroupEnd, log: "", depth: 0},
+				{kind: data.LogKindStepNone, log: "FAIL\tgithub.com/dlvhdr/gh-enhance/internal/tui\t0.123s"},
+				{kind: data.LogKindError, log: "##[error]Process completed with exit code 1."},
+			},
+		},
+		{
+			name: "warning, notice, debug and section markers",
+			logs: "2025-06-01T10:00:00.0000000Z ##[section]Starting: Initialize job\n" +
+				"2025-06-01T10:00:00.1000000Z ##[debug]Evaluating condition for step: 'Run tests'\n" +
+				"2025-06-01T10:00:00.2000000Z ##[warning]The `set-output` command is deprecated and will be disabled soon.\n" +
+				"2025-06-01T10:00:00.3000000Z ##[notice]Node.js 16 actions are deprecated.\n",
+			want: []wantLine{
+				{kind: data.LogKindSection, log: "##[section]Starting: Initialize job"},
+				{kind: data.LogKindDebug, log: "##[debug]Evaluating condition for step: 'Run tests'"},
+				{kind: data.LogKindWarning,
+					log: "##[warning]The `set-output` command is deprecated and will be disabled soon."},
+				{kind: data.LogKindNotice, log: "##[notice]Node.js 16 actions are deprecated."},
+			},
+		},
+		{
+			name: "workflow commands with properties",
+			logs: "2025-06-01T10:00:03.0000000Z ::error file=internal/tui/tui.go,line=120,col=5,endColumn=12,title=typecheck::undefined: foo\n" +
+				"2025-06-01T10:00:03.1000000Z ::warning file=app.js,line=1,endLine=3::Missing semicolon\n" +
+				"2025-06-01T10:00:03.2000000Z ::notice title=Coverage%3A 80%25::Coverage dropped%2C see the report\n" +
+				"2025-06-01T10:00:03.3000000Z ::debug::Set output 'version'\n",
+			want: []wantLine{
+				{
+					kind: data.LogKindError,
+					log:  "##[error]undefined: foo",
+					props: data.LogProperties{Title: "typecheck", File: "internal/tui/tui.go",
+						Line: 120, Col: 5, EndColumn: 12},
+				},
+				{
+					kind:  data.LogKindWarning,
+					log:   "##[warning]Missing semicolon",
+					props: data.LogProperties{File: "app.js", Line: 1, EndLine: 3},
+				},
+				{
+					kind:  data.LogKindNotice,
+					log:   "##[notice]Coverage dropped%2C see the report",
+					props: data.LogProperties{Title: "Coverage: 80%"},
+				},
+				{kind: data.LogKindDebug, log: "##[debug]Set output 'version'"},
+			},
+		},
+		{
+			name: "group commands",
+			logs: "2025-06-01T10:00:03.0000000Z ::group::Installing dependencies\n" +
+				"2025-06-01T10:00:04.0000000Z added 120 packages in 3s\n" +
+				"2025-06-01T10:00:04.1000000Z ::endgroup::\n",
+			want: []wantLine{
+				{kind: data.LogKindGroupStart, log: "##[group]Installing dependencies", depth: 1},
+				{kind: data.LogKindStepNone, log: "added 120 packages in 3s", depth: 1},
+				{kind: data.LogKindGroupEnd, log: ""},
+			},
+		},
+		{
+			name: "masked values",
+			logs: "2025-06-01T10:00:03.0000000Z ::add-mask::s3cr3t-t0k3n\n" +
+				"2025-06-01T10:00:03.1000000Z curl -H 'Authorization: Bearer s3cr3t-t0k3n' https://example.com\n",
+			want: []wantLine{
+				{kind: data.LogKindMask, log: "::add-mask::***"},
+				{kind: data.LogKindStepNone, log: "curl -H 'Authorization: Bearer ***' https://example.com"},
+			},
+		},
+		{
+			name: "stopped commands",
+			logs: "2025-06-01T10:00:03.0000000Z ::stop-commands::pause-logging\n" +
+				"2025-06-01T10:00:03.1000000Z ::error::not an error while commands are stopped\n" +
+				"2025-06-01T10:00:03.2000000Z ::pause-logging::\n" +
+				"2025-06-01T10:00:03.3000000Z ::error::an error again\n",
+			want: []wantLine{
+				{kind: data.LogKindStopCommands, log: "::stop-commands::pause-logging"},
+				{kind: data.LogKindStepNone, log: "::error::not an error while commands are stopped"},
+				{kind: data.LogKindStopCommands, log: "::pause-logging::"},
+				{kind: data.LogKindError, log: "##[error]an error again"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseJobLogs(tt.logs)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d lines, got %d: %+v", len(tt.want), len(got), got)
+			}
+			for i, want := range tt.want {
+				if got[i].Kind != want.kind {
+					t.Errorf("line %d: expected kind %d, got %d", i, want.kind, got[i].Kind)
+				}
+				if got[i].Log != want.log {
+					t.Errorf("line %d: expected %q, got %q", i, want.log, got[i].Log)
+				}
+				if got[i].Depth != want.depth {
+					t.Errorf("line %d: expected depth %d, got %d", i, want.depth, got[i].Depth)
+				}
+				if got[i].Properties != want.props {
+					t.Errorf("line %d: expected properties %+v, got %+v", i, want.props, got[i].Properties)
+				}
+			}
+		})
+	}
+}
+
+func TestLogPropertiesLocation(t *testing.T) {
+	tests := []struct {
+		props data.LogProperties
+		want  string
+	}{
+		{data.LogProperties{}, ""},
+		{data.LogProperties{Line: 3}, ""},
+		{data.LogProperties{File: "main.go"}, "main.go"},
+		{data.LogProperties{File: "main.go", Line: 12}, "main.go:12"},
+		{data.LogProperties{File: "main.go", Line: 12, Col: 5}, "main.go:12:5"},
+	}
+	for _, tt := range tests {
+		if got := tt.props.Location(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cli/go-gh/v2/pkg/repository"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// maxAnnotationRows is the number of annotations shown at once above the logs
+const maxAnnotationRows = 5
+
+type annotationsFetchedMsg struct {
+	jobId       string
+	annotations []api.Annotation
+	err         error
+}
+
+func (m *model) makeFetchAnnotationsCmd(ji *jobItem) tea.Cmd {
+	if ji.fetchedAnnotations || ji.isStatusInProgress() || ji.job.Kind == data.JobKindExternal ||
+		ji.job.Kind == data.JobKindStatusContext {
+		return nil
+	}
+
+	ji.fetchedAnnotations = true
+	jobId := ji.job.Id
+	return func() tea.Msg {
+		annotations, err := m.client.FetchAnnotations(m.repo, jobId)
+		if err != nil {
+			log.Error("error fetching annotations", "jobId", jobId, "err", err)
+		}
+		return annotationsFetchedMsg{jobId: jobId, annotations: annotations, err: err}
+	}
+}
+
+func (m *model) onAnnotationsFetched(msg annotationsFetchedMsg) tea.Cmd {
+	ji := m.getJobItemById(msg.jobId)
+	if ji == nil {
+		return nil
+	}
+	if msg.err != nil {
+		// fetched again the next time the job is selected
+		ji.fetchedAnnotations = false
+		return nil
+	}
+	if len(msg.annotations) == 0 {
+		return nil
+	}
+
+	ji.annotations = msg.annotations
+	// the logs are rendered again to mark the annotated lines
+	ji.renderedLogs, ji.unstyledLogs = nil, nil
+	if curr := m.getSelectedJobItem(); curr == nil || curr.job.Id != msg.jobId {
+		return nil
+	}
+
+	m.annotationsCursor = 0
+	cmd := m.renderJobLogs()
+	m.setHeights()
+	return cmd
+}
+
+// matchAnnotations returns the index of the log line each annotation was printed
+// at, or -1 when it isn't found. Only the error, warning and notice lines the workflow
+// commands creating annotations print are matched, by the annotation's message and,
+// when the command set them, its file and line.
+func matchAnnotations(logs []data.LogsWithTime, annotations []api.Annotation) []int {
+	lines := make([]int, len(annotations))
+	for i, annotation := range annotations {
+		lines[i] = -1
+		message, _, _ := strings.Cut(strings.TrimSpace(annotation.Message), "\n")
+		if message == "" {
+			continue
+		}
+		for j, l := range logs {
+			if annotationMatchesLine(annotation, message, l) {
+				lines[i] = j
+				break
+			}
+		}
+	}
+	return lines
+}
+
+// annotationMatchesLine reports whether a log line is the command that created the annotation
+func annotationMatchesLine(a api.Annotation, message string, l data.LogsWithTime) bool {
+	switch l.Kind {
+	case data.LogKindError, data.LogKindWarning, data.LogKindNotice:
+	default:
+		return false
+	}
+	if l.Properties.File != "" && l.Properties.File != a.Path {
+		return false
+	}
+	if l.Properties.Line != 0 && l.Properties.Line != a.StartLine {
+		return false
+	}
+	return strings.Contains(ansi.Strip(l.Log), message)
+}
+
+// annotationAtLine returns the annotation printed at a log line of the job
+func (ji *jobItem) annotationAtLine(line int) (api.Annotation, bool) {
+	for i, l := range ji.annotationLines {
+		if l == line {
+			return ji.annotations[i], true
+		}
+	}
+	return api.Annotation{}, false
+}
+
+func (m *model) annotationGlyph(level api.AnnotationLevel) string {
+	switch level {
+	case api.AnnotationFailure:
+		return m.styles.failureGlyph.String()
+	case api.AnnotationWarning:
+		return m.styles.warningGlyph.String()
+	default:
+		return m.styles.noticeGlyph.String()
+	}
+}
+
+func annotationIcon(level api.AnnotationLevel) string {
+	switch level {
+	case api.AnnotationFailure:
+		return FailureIcon
+	case api.AnnotationWarning:
+		return WarningIcon
+	default:
+		return NoticeIcon
+	}
+}
+
+// annotationLocation returns the file and line of an annotation, e.g. main.go:12
+func annotationLocation(a api.Annotation) string {
+	if a.StartLine == 0 {
+		return a.Path
+	}
+	return fmt.Sprintf("%s:%d", a.Path, a.StartLine)
+}
+
+func (m *model) shouldShowAnnotations() bool {
+	ji := m.getSelectedJobItem()
+	return m.artifactPreview == nil && !m.showingSummary() && ji != nil && len(ji.annotations) > 0
+}
+
+func (m *model) annotationsHeight() int {
+	if !m.shouldShowAnnotations() {
+		return 0
+	}
+	return paneTitleHeight + min(len(m.getSelectedJobItem().annotations), maxAnnotationRows) + 1
+}
+
+func (m *model) viewAnnotations() string {
+	if !m.shouldShowAnnotations() {
+		return ""
+	}
+	ji := m.getSelectedJobItem()
+
+	title := makePill("Annotations", m.styles.unfocusedPaneTitleStyle, m.styles.colors.unfocusedColor)
+	titleBarStyle := m.styles.unfocusedPaneTitleBarStyle
+	if m.focusedPane == PaneAnnotations {
+		title = makePill("Annotations", m.styles.focusedPaneTitleStyle, m.styles.colors.focusedColor)
+		titleBarStyle = m.styles.focusedPaneTitleBarStyle
+	}
+	title = lipgloss.JoinHorizontal(lipgloss.Top, titleBarStyle.MarginBottom(0).Render(title), " ",
+		m.styles.faintFgStyle.Render(strconv.Itoa(len(ji.annotations))))
+
+	w := m.logsWidth()
+	rows := []string{title}
+	start := max(0, m.annotationsCursor-maxAnnotationRows+1)
+	end := min(len(ji.annotations), start+maxAnnotationRows)
+	for i, a := range ji.annotations[start:end] {
+		message := a.Title
+		if message == "" {
+			message, _, _ = strings.Cut(a.Message, "\n")
+		}
+
+		location := annotationLocation(a)
+		row := m.annotationGlyph(a.Level) + " "
+		if start+i == m.annotationsCursor && m.focusedPane == PaneAnnotations {
+			row += lipgloss.NewStyle().Foreground(m.styles.colors.focusedColor).Bold(true).
+				Render(location)
+		} else {
+			row += m.styles.faintFgStyle.Render(location)
+		}
+		rows = append(rows, ansi.Truncate(row+" "+message, w, Ellipsis))
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// onAnnotationChanged scrolls the logs to the line the selected annotation was printed at
+func (m *model) onAnnotationChanged() {
+	ji := m.getSelectedJobItem()
+	if ji == nil || m.annotationsCursor >= len(ji.annotationLines) {
+		return
+	}
+	if line := ji.annotationLines[m.annotationsCursor]; line >= 0 && line < len(ji.renderedLogs) {
+		m.goToLogLine(ji, line)
+	}
+}
+
+func (m *model) updateAnnotations(msg tea.KeyPressMsg) tea.Cmd {
+	ji := m.getSelectedJobItem()
+	if ji == nil || len(ji.annotations) == 0 {
+		return nil
+	}
+
+	switch {
+	case key.Matches(msg, nextRowKey):
+		m.annotationsCursor = min(m.annotationsCursor+1, len(ji.annotations)-1)
+		m.onAnnotationChanged()
+	case key.Matches(msg, prevRowKey):
+		m.annotationsCursor = max(m.annotationsCursor-1, 0)
+		m.onAnnotationChanged()
+	case key.Matches(msg, openAnnotationKey):
+		return m.makeOpenAnnotationCmd(ji.annotations[m.annotationsCursor])
+	}
+	return nil
+}
+
+type editorClosedMsg struct {
+	err error
+}
+
+// makeOpenAnnotationCmd opens the annotated file in $EDITOR when the repo is checked
+// out in the working directory.
+func (m *model) makeOpenAnnotationCmd(a api.Annotation) tea.Cmd {
+	root, ok := m.localCheckout()
+	if !ok {
+		log.Info("not opening annotation, the repo isn't checked out here", "repo", m.repo)
+		return nil
+	}
+
+	path := filepath.Join(root, filepath.FromSlash(a.Path))
+	// annotations can't open files outside of the checkout
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		log.Info("not opening annotation, its path is outside of the repo", "path", a.Path)
+		return nil
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		log.Info("not opening annotation, its file doesn't exist", "path", path)
+		return nil
+	}
+
+	cmd := editorCommand(path, a.StartLine)
+	log.Info("opening annotation in editor", "cmd", cmd.Args)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorClosedMsg{err: err}
+	})
+}
+
+// localCheckout returns the root of the working directory's git repo when
+// it's a clone of the repo being viewed.
+func (m *model) localCheckout() (string, bool) {
+	r, err := repository.Current()
+	if err != nil || !strings.EqualFold(r.Owner+"/"+r.Name, m.repo) ||
+		!strings.EqualFold(r.Host, m.client.Host()) {
+		return "", false
+	}
+
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(out)), true
+}
+
+// editorCommand opens a file at a line with the user's editor. Most editors
+// accept +line, VS Code based ones take --goto file:line instead.
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := os.Getenv("GH_EDITOR")
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor == "" {
+			editor = os.Getenv(env)
+		}
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	args := strings.Fields(editor)
+	switch filepath.Base(args[0]) {
+	case "code", "code-insiders", "cursor", "codium":
+		args = append(args, "--goto", fmt.Sprintf("%s:%d", path, max(1, line)))
+	default:
+		if line > 0 {
+			args = append(args, fmt.Sprintf("+%d", line))
+		}
+		args = append(args, path)
+	}
+	return exec.Command(args[0], args[1:]...)
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestAnnotationsMarkTheirLogLines(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "lint", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+	if ji := m.getJobItemById("11"); !ji.fetchedAnnotations {
+		t.Error("expected the annotations of the selected job to be fetched")
+	}
+
+	logs := make([]data.LogsWithTime, 0)
+	for i := range 100 {
+		logs = append(logs, data.LogsWithTime{Log: "line " + strconv.Itoa(i)})
+	}
+	logs[20].Log = "echo unused variable"
+	logs[40] = data.LogsWithTime{
+		Log:        "##[warning]unused variable",
+		Kind:       data.LogKindWarning,
+		Properties: data.LogProperties{File: "lib.go", Line: 3},
+	}
+	logs[60] = data.LogsWithTime{
+		Log:        "##[warning]unused variable",
+		Kind:       data.LogKindWarning,
+		Properties: data.LogProperties{File: "main.go", Line: 12, Col: 5},
+	}
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: logs})
+	m = updated.(model)
+
+	updated, _ = m.Update(annotationsFetchedMsg{jobId: "11", annotations: []api.Annotation{
+		{Path: "main.go", StartLine: 12, Level: api.AnnotationWarning, Message: "unused variable"},
+		{Path: ".github", Level: api.AnnotationNotice, Message: "not printed in the logs"},
+	}})
+	m = updated.(model)
+
+	ji := m.getJobItemById("11")
+	if !slices.Equal(ji.annotationLines, []int{60, -1}) {
+		t.Fatalf("expected the annotations to match the lines [60 -1], got %v", ji.annotationLines)
+	}
+	if got := ji.unstyledLogs[60]; got != WarningIcon+" Warning (main.go:12:5): unused variable" {
+		t.Errorf("expected the annotated line to be marked, got %q", got)
+	}
+	if got := ji.unstyledLogs[59]; got != "line 59" {
+		t.Errorf("expected the other lines to be left as is, got %q", got)
+	}
+
+	m.focusPane(PaneLogs)
+	m.focusPane(m.previousPane())
+	if m.focusedPane != PaneAnnotations {
+		t.Fatalf("expected the annotations pane to be focused, got %d", m.focusedPane)
+	}
+	m.logsViewport.GotoTop()
+	for _, k := range []rune{'j', 'k'} {
+		updated, _ = m.Update(tea.KeyPressMsg{Code: k, Text: string(k)})
+		m = updated.(model)
+	}
+	if m.annotationsCursor != 0 {
+		t.Errorf("expected the first annotation to be selected, got %d", m.annotationsCursor)
+	}
+	if got := m.logsViewport.YOffset(); got != 60-3 {
+		t.Errorf("expected the logs to be scrolled to the annotated line, got offset %d", got)
+	}
+}
+
+func TestAnnotationsAreFetchedAgainAfterAnError(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "lint", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(annotationsFetchedMsg{jobId: "11", err: errors.New("bad gateway")})
+	m = updated.(model)
+
+	ji := m.getJobItemById("11")
+	if ji.fetchedAnnotations {
+		t.Error("expected the failed fetch not to count as fetched")
+	}
+	if m.makeFetchAnnotationsCmd(ji) == nil {
+		t.Error("expected the annotations to be fetched again")
+	}
+}
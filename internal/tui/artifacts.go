@@ -0,0 +1,529 @@
+package tui
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// maxArtifactPreviewSize is the size of the largest artifact that can be previewed,
+// bigger ones have to be downloaded
+const maxArtifactPreviewSize = 5 * 1024 * 1024
+
+const progressBarWidth = 30
+
+type artifactAction int
+
+const (
+	artifactActionNone artifactAction = iota
+	artifactActionDownload
+	artifactActionPreview
+)
+
+// artifactsBrowser lists the artifacts of a run to download or preview them.
+// The downloads and previews are started by the caller.
+type artifactsBrowser struct {
+	runId     string
+	runName   string
+	artifacts []api.Artifact
+	cursor    int
+	dir       textinput.Model // the directory to extract to, focused while choosing it
+	download  *artifactDownload
+	status    string // the outcome of the last download
+	loading   bool
+	err       error
+	styles    styles
+}
+
+type artifactDownload struct {
+	artifact api.Artifact
+	dir      string
+	written  int64
+}
+
+func newArtifactsBrowser(s styles, run *data.WorkflowRun) *artifactsBrowser {
+	return &artifactsBrowser{
+		runId:   run.Id,
+		runName: run.Name,
+		dir:     newFormInput(s, "directory"),
+		loading: true,
+		styles:  s,
+	}
+}
+
+func (b *artifactsBrowser) setArtifacts(artifacts []api.Artifact, err error) {
+	b.loading = false
+	b.err = err
+	b.artifacts = artifacts
+}
+
+func (b *artifactsBrowser) selected() api.Artifact {
+	return b.artifacts[b.cursor]
+}
+
+// Update moves between the artifacts and edits the download directory. It returns
+// what to do with the selected artifact once it's chosen.
+func (b *artifactsBrowser) Update(msg tea.KeyPressMsg) (tea.Cmd, artifactAction) {
+	if b.dir.Focused() {
+		switch {
+		case key.Matches(msg, closeFormKey):
+			b.dir.Blur()
+		case key.Matches(msg, submitFormKey):
+			if strings.TrimSpace(b.dir.Value()) == "" {
+				b.err = errors.New("choose a directory to download to")
+				return nil, artifactActionNone
+			}
+			b.dir.Blur()
+			b.err = nil
+			return nil, artifactActionDownload
+		default:
+			var cmd tea.Cmd
+			b.dir, cmd = b.dir.Update(msg)
+			return cmd, artifactActionNone
+		}
+		return nil, artifactActionNone
+	}
+
+	if b.loading || len(b.artifacts) == 0 {
+		return nil, artifactActionNone
+	}
+
+	switch {
+	case key.Matches(msg, nextRowKey):
+		b.cursor = min(b.cursor+1, len(b.artifacts)-1)
+	case key.Matches(msg, prevRowKey):
+		b.cursor = max(b.cursor-1, 0)
+	case key.Matches(msg, downloadArtifactKey):
+		if b.download != nil {
+			b.err = errors.New("wait for the current download to finish")
+			return nil, artifactActionNone
+		}
+		if b.selected().Expired {
+			b.err = errors.New("the artifact expired and can no longer be downloaded")
+			return nil, artifactActionNone
+		}
+		b.err = nil
+		b.dir.SetValue("." + string(os.PathSeparator) + b.selected().Name)
+		b.dir.CursorEnd()
+		return b.dir.Focus(), artifactActionNone
+	case key.Matches(msg, previewArtifactKey):
+		a := b.selected()
+		switch {
+		case a.Expired:
+			b.err = errors.New("the artifact expired and can no longer be previewed")
+		case a.SizeInBytes > maxArtifactPreviewSize:
+			b.err = fmt.Errorf("the artifact is too big to preview, download it instead (max %s)",
+				formatBytes(maxArtifactPreviewSize))
+		default:
+			b.err = nil
+			return nil, artifactActionPreview
+		}
+	}
+	return nil, artifactActionNone
+}
+
+func (b *artifactsBrowser) View() string {
+	focusedStyle := lipgloss.NewStyle().Foreground(b.styles.colors.focusedColor).Bold(true)
+
+	rows := []string{lipgloss.NewStyle().Bold(true).MarginBottom(1).Render(
+		"Artifacts of " + b.runName)}
+	switch {
+	case b.loading:
+		rows = append(rows, b.styles.faintFgStyle.Render("Fetching the artifacts…"))
+	case len(b.artifacts) == 0 && b.err == nil:
+		rows = append(rows, b.styles.faintFgStyle.Render("This run has no artifacts"))
+	}
+
+	nameWidth := 0
+	for _, a := range b.artifacts {
+		nameWidth = max(nameWidth, lipgloss.Width(a.Name))
+	}
+	for i, a := range b.artifacts {
+		name := lipgloss.NewStyle().Width(nameWidth).Render(a.Name)
+		if i == b.cursor && !b.dir.Focused() {
+			name = focusedStyle.Render("> " + name)
+		} else {
+			name = "  " + name
+		}
+
+		details := fmt.Sprintf("%9s  %s", formatBytes(a.SizeInBytes), formatExpiry(a))
+		rows = append(rows, name+"  "+b.styles.faintFgStyle.Render(details))
+	}
+
+	if b.dir.Focused() {
+		rows = append(rows, lipgloss.NewStyle().MarginTop(1).Render(lipgloss.JoinHorizontal(
+			lipgloss.Top, focusedStyle.Width(10).Render("Save to"), b.dir.View())))
+	}
+
+	if b.download != nil {
+		rows = append(rows, lipgloss.NewStyle().MarginTop(1).Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			"Downloading "+b.download.artifact.Name+" to "+b.download.dir,
+			b.progressView(),
+		)))
+	} else if b.status != "" {
+		rows = append(rows, lipgloss.NewStyle().
+			MarginTop(1).
+			Width(60).
+			Foreground(b.styles.colors.successColor).
+			Render(b.status))
+	}
+
+	if b.err != nil {
+		rows = append(rows, lipgloss.NewStyle().
+			MarginTop(1).
+			Width(60).
+			Foreground(b.styles.colors.errorColor).
+			Render(b.err.Error()))
+	}
+
+	help := "j/k move • d download • enter preview • esc close"
+	if b.dir.Focused() {
+		help = "enter download • esc cancel"
+	}
+	rows = append(rows, b.styles.faintFgStyle.MarginTop(1).Render(help))
+
+	return b.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func (b *artifactsBrowser) progressView() string {
+	total := b.download.artifact.SizeInBytes
+	ratio := 0.0
+	if total > 0 {
+		ratio = min(1, float64(b.download.written)/float64(total))
+	}
+
+	filled := int(ratio * progressBarWidth)
+	return lipgloss.NewStyle().Foreground(b.styles.colors.focusedColor).
+		Render(strings.Repeat("█", filled)) +
+		b.styles.faintFgStyle.Render(strings.Repeat("░", progressBarWidth-filled)) +
+		fmt.Sprintf(" %3.0f%% %s/%s", ratio*100, formatBytes(b.download.written), formatBytes(total))
+}
+
+// formatBytes formats a size in bytes the way GitHub shows them, e.g. 1.2 MB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatExpiry returns when an artifact expires, e.g. expires in 3 days
+func formatExpiry(a api.Artifact) string {
+	left := time.Until(a.ExpiresAt)
+	switch {
+	case a.Expired || left <= 0:
+		return "expired"
+	case left >= 48*time.Hour:
+		return fmt.Sprintf("expires in %d days", int(left.Hours()/24))
+	case left >= 2*time.Hour:
+		return fmt.Sprintf("expires in %d hours", int(left.Hours()))
+	default:
+		return "expires soon"
+	}
+}
+
+type artifactsFetchedMsg struct {
+	runId     string
+	artifacts []api.Artifact
+	err       error
+}
+
+func (m *model) makeFetchArtifactsCmd(runId string) tea.Cmd {
+	return func() tea.Msg {
+		artifacts, err := m.client.FetchArtifacts(m.repo, runId)
+		if err != nil {
+			log.Error("error fetching artifacts", "runId", runId, "err", err)
+		}
+		return artifactsFetchedMsg{runId: runId, artifacts: artifacts, err: err}
+	}
+}
+
+type artifactDownloadProgressMsg struct {
+	artifactId int64
+	written    int64
+	updates    <-chan tea.Msg
+}
+
+type artifactDownloadedMsg struct {
+	artifact api.Artifact
+	dir      string
+	files    []string
+	err      error
+}
+
+// makeDownloadArtifactCmd downloads an artifact and extracts it to dir. The progress
+// is reported with artifactDownloadProgressMsg until an artifactDownloadedMsg is sent.
+func (m *model) makeDownloadArtifactCmd(a api.Artifact, dir string) tea.Cmd {
+	log.Info("downloading artifact", "name", a.Name, "dir", dir)
+	updates := make(chan tea.Msg, 1)
+	go func() {
+		defer close(updates)
+		files, err := m.downloadArtifact(a, dir, func(written int64) {
+			// progress is dropped while the previous update is yet to be handled
+			select {
+			case updates <- artifactDownloadProgressMsg{artifactId: a.Id, written: written, updates: updates}:
+			default:
+			}
+		})
+		updates <- artifactDownloadedMsg{artifact: a, dir: dir, files: files, err: err}
+	}()
+	return waitForArtifactDownload(updates)
+}
+
+func waitForArtifactDownload(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+func (m *model) downloadArtifact(a api.Artifact, dir string, progress func(int64)) ([]string, error) {
+	archive, err := os.CreateTemp("", "gh-enhance-artifact-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	w := &progressWriter{w: archive, progress: progress}
+	if err := m.client.DownloadArtifact(m.repo, a.Id, w); err != nil {
+		return nil, err
+	}
+
+	r, err := zip.NewReader(archive, w.written)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the artifact's archive: %w", err)
+	}
+	return extractZip(r, dir)
+}
+
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	progress func(int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	pw.progress(pw.written)
+	return n, err
+}
+
+// extractZip extracts an archive to dir and returns the paths of the extracted files
+func extractZip(r *zip.Reader, dir string) ([]string, error) {
+	files := make([]string, 0, len(r.File))
+	for _, f := range r.File {
+		path := filepath.Join(dir, filepath.FromSlash(f.Name))
+		// archives can't write outside of dir
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			return files, fmt.Errorf("invalid path in the artifact's archive: %s", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return files, err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return files, err
+		}
+		if err := extractFile(f, path); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+func extractFile(f *zip.File, path string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func (m *model) onArtifactDownloadProgress(msg artifactDownloadProgressMsg) tea.Cmd {
+	if b := m.artifactsBrowser; b != nil && b.download != nil &&
+		b.download.artifact.Id == msg.artifactId {
+		b.download.written = msg.written
+	}
+	return waitForArtifactDownload(msg.updates)
+}
+
+func (m *model) onArtifactDownloaded(msg artifactDownloadedMsg) {
+	if msg.err != nil {
+		log.Error("error downloading artifact", "name", msg.artifact.Name, "err", msg.err)
+	} else {
+		log.Info("artifact downloaded", "name", msg.artifact.Name, "dir", msg.dir, "files", len(msg.files))
+	}
+
+	b := m.artifactsBrowser
+	if b == nil || b.download == nil || b.download.artifact.Id != msg.artifact.Id {
+		return
+	}
+	b.download = nil
+	b.err = msg.err
+	if msg.err == nil {
+		b.status = fmt.Sprintf("Downloaded %s to %s (%d files)", msg.artifact.Name, msg.dir,
+			len(msg.files))
+	}
+}
+
+// artifactPreview is an artifact's text files shown in place of the logs
+type artifactPreview struct {
+	name  string
+	lines []string
+}
+
+type artifactPreviewedMsg struct {
+	artifact api.Artifact
+	lines    []string
+	err      error
+}
+
+func (m *model) makePreviewArtifactCmd(a api.Artifact) tea.Cmd {
+	log.Info("previewing artifact", "name", a.Name)
+	return func() tea.Msg {
+		lines, err := m.previewArtifact(a)
+		return artifactPreviewedMsg{artifact: a, lines: lines, err: err}
+	}
+}
+
+// previewArtifact downloads the artifact to a temporary file, as downloadArtifact does,
+// and returns the lines of its files
+func (m *model) previewArtifact(a api.Artifact) ([]string, error) {
+	if a.SizeInBytes > maxArtifactPreviewSize {
+		return nil, fmt.Errorf("the artifact is too big to preview, download it instead (max %s)",
+			formatBytes(maxArtifactPreviewSize))
+	}
+
+	archive, err := os.CreateTemp("", "gh-enhance-artifact-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	w := &progressWriter{w: archive, progress: func(int64) {}}
+	if err := m.client.DownloadArtifact(m.repo, a.Id, w); err != nil {
+		return nil, err
+	}
+
+	r, err := zip.NewReader(archive, w.written)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the artifact's archive: %w", err)
+	}
+	return previewZip(r)
+}
+
+// previewZip returns the lines of the files in an archive, each file is preceded
+// by its name when there are a few. Binary files are listed but not shown.
+func previewZip(r *zip.Reader) ([]string, error) {
+	files := make([]*zip.File, 0, len(r.File))
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() {
+			files = append(files, f)
+		}
+	}
+
+	lines := make([]string, 0)
+	for i, f := range files {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxArtifactPreviewSize))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(files) > 1 {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, "==> "+f.Name+" <==")
+		}
+		// binary files are detected the way git does, by a NUL byte at their start
+		if bytes.IndexByte(content[:min(len(content), 8000)], 0) != -1 {
+			lines = append(lines, "(binary file, download the artifact to view it)")
+			continue
+		}
+		text := strings.ReplaceAll(strings.TrimRight(string(content), "\n"), "\r\n", "\n")
+		lines = append(lines, strings.Split(text, "\n")...)
+	}
+	return lines, nil
+}
+
+func (m *model) onArtifactPreviewed(msg artifactPreviewedMsg) tea.Cmd {
+	if msg.err != nil {
+		log.Error("error previewing artifact", "name", msg.artifact.Name, "err", msg.err)
+		if m.artifactsBrowser != nil {
+			m.artifactsBrowser.err = msg.err
+		}
+		return nil
+	}
+
+	m.artifactsBrowser = nil
+	m.artifactPreview = &artifactPreview{name: msg.artifact.Name, lines: msg.lines}
+	m.focusPane(PaneLogs)
+	cmd := m.renderJobLogs()
+	m.logsViewport.GotoTop()
+	return cmd
+}
+
+// closeArtifactPreview shows the logs of the selected job again
+func (m *model) closeArtifactPreview() tea.Cmd {
+	m.artifactPreview = nil
+	if ji := m.getSelectedJobItem(); ji != nil {
+		// logs fetched during the preview weren't rendered
+		ji.renderedLogs, ji.unstyledLogs = nil, nil
+	}
+	cmd := m.renderJobLogs()
+	m.goToErrorInLogs()
+	return cmd
+}
+
+// startArtifactDownload downloads the browser's selected artifact to the chosen directory
+func (m *model) startArtifactDownload(b *artifactsBrowser) tea.Cmd {
+	a := b.selected()
+	dir := strings.TrimSpace(b.dir.Value())
+	b.download = &artifactDownload{artifact: a, dir: dir}
+	b.status = ""
+	return m.makeDownloadArtifactCmd(a, dir)
+}
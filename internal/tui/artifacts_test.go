@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"archive/zip"
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func newTestZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	buf := bytes.Buffer{}
+	w := zip.NewWriter(&buf)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestExtractArtifact(t *testing.T) {
+	dir := t.TempDir()
+	files, err := extractZip(newTestZip(t, map[string]string{
+		"report.xml":        "<testsuites/>",
+		"coverage/index.md": "# Coverage",
+	}), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 extracted files, got %v", files)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "coverage", "index.md"))
+	if err != nil || string(content) != "# Coverage" {
+		t.Errorf("expected the nested file to be extracted, got %q %v", content, err)
+	}
+
+	_, err = extractZip(newTestZip(t, map[string]string{"../escaped.txt": "nope"}), dir)
+	if err == nil {
+		t.Error("expected files outside of the directory to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escaped.txt")); err == nil {
+		t.Error("expected no file to be written outside of the directory")
+	}
+}
+
+func TestPreviewingArtifact(t *testing.T) {
+	lines, err := previewZip(newTestZip(t, map[string]string{
+		"summary.txt": "ok 12 tests\r\nfail 1 test\n",
+		"report.bin":  "\x00\x01",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"==> report.bin <==",
+		"(binary file, download the artifact to view it)",
+		"",
+		"==> summary.txt <==",
+		"ok 12 tests",
+		"fail 1 test",
+	}
+	if !slices.Equal(lines, want) {
+		t.Fatalf("expected the preview %q, got %q", want, lines)
+	}
+
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "unit", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'A', Text: "A"})
+	m = updated.(model)
+	if m.artifactsBrowser == nil {
+		t.Fatal("expected the artifacts browser to open")
+	}
+	artifact := api.Artifact{Id: 42, Name: "test-results", SizeInBytes: 512}
+	updated, _ = m.Update(artifactsFetchedMsg{runId: "1", artifacts: []api.Artifact{artifact}})
+	m = updated.(model)
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+	if cmd == nil {
+		t.Fatal("expected the artifact to be fetched for the preview")
+	}
+
+	updated, _ = m.Update(artifactPreviewedMsg{artifact: artifact, lines: lines})
+	m = updated.(model)
+	if m.artifactsBrowser != nil {
+		t.Error("expected the artifacts browser to close")
+	}
+	if m.focusedPane != PaneLogs {
+		t.Errorf("expected the logs pane to be focused, got %d", m.focusedPane)
+	}
+	if got := ansi.Strip(m.logsViewport.GetContent()); !strings.Contains(got, "fail 1 test") {
+		t.Errorf("expected the preview to be shown in the logs, got %q", got)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(model)
+	if m.artifactPreview != nil {
+		t.Error("expected esc to close the preview")
+	}
+
+	big := api.Artifact{Id: 43, Name: "coverage", SizeInBytes: maxArtifactPreviewSize + 1}
+	if _, err := m.previewArtifact(big); err == nil || !strings.Contains(err.Error(), "too big") {
+		t.Errorf("expected an artifact over the preview size to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+type runAttemptFetchedMsg struct {
+	runId   string
+	attempt int
+	latest  int
+	run     data.WorkflowRun // the run as it was in the attempt
+	jobs    []data.WorkflowJob
+	err     error
+}
+
+// latestAttempt returns the number of attempts of the run, 0 when it isn't known yet.
+// Runs of PRs come from GraphQL which doesn't have it, so it's learnt from their jobs.
+func (i *runItem) latestAttempt() int {
+	latest := max(i.attempts, i.run.RunAttempt)
+	for _, job := range i.run.Jobs {
+		latest = max(latest, job.RunAttempt)
+	}
+	return latest
+}
+
+// shownAttempt returns the attempt whose jobs are shown
+func (i *runItem) shownAttempt() int {
+	if i.attempt != 0 {
+		return i.attempt
+	}
+	return i.latestAttempt()
+}
+
+// shownRun returns the run as it was in the shown attempt, with its own status and conclusion
+func (i *runItem) shownRun() *data.WorkflowRun {
+	if i.attempt != 0 && i.attemptRun != nil {
+		return i.attemptRun
+	}
+	return i.run
+}
+
+// cycleRunAttempt shows the jobs of the previous (delta -1) or next (delta 1) attempt of the selected run
+func (m *model) cycleRunAttempt(delta int) tea.Cmd {
+	ri := m.getSelectedRunItem()
+	if ri == nil || !ri.run.IsActionsRun() || ri.loadingJobs {
+		return nil
+	}
+
+	latest := ri.latestAttempt()
+	if latest == 0 {
+		if delta > 0 {
+			return nil
+		}
+		// the number of attempts has to be fetched first
+		ri.loadingJobs = true
+		return tea.Batch(m.jobsList.StartSpinner(), m.makeFetchRunAttemptCmd(*ri.run, 0))
+	}
+
+	attempt := ri.shownAttempt() + delta
+	if attempt < 1 || attempt > latest {
+		return nil
+	}
+
+	ri.loadingJobs = true
+	return tea.Batch(m.jobsList.StartSpinner(), m.makeFetchRunAttemptCmd(*ri.run, attempt))
+}
+
+// makeFetchRunAttemptCmd fetches the jobs of an attempt of the run. An attempt of 0
+// fetches the one before the latest.
+func (m *model) makeFetchRunAttemptCmd(run data.WorkflowRun, attempt int) tea.Cmd {
+	return func() tea.Msg {
+		latest, err := m.client.FetchWorkflowRunByID(m.repo, run.Id)
+		if err != nil {
+			log.Error("error fetching workflow run", "runId", run.Id, "err", err)
+			return runAttemptFetchedMsg{runId: run.Id, err: err}
+		}
+		if attempt == 0 {
+			attempt = latest.RunAttempt - 1
+		}
+		if attempt < 1 {
+			return runAttemptFetchedMsg{runId: run.Id, latest: latest.RunAttempt,
+				err: fmt.Errorf("run %s has a single attempt", run.Id)}
+		}
+
+		attemptRun, err := m.client.FetchWorkflowRunAttempt(m.repo, run.Id, attempt)
+		if err != nil {
+			log.Error("error fetching workflow run attempt", "runId", run.Id,
+				"attempt", attempt, "err", err)
+			return runAttemptFetchedMsg{runId: run.Id, latest: latest.RunAttempt, err: err}
+		}
+
+		jobsResp, err := m.client.FetchWorkflowRunAttemptJobs(m.repo, run.Id, attempt)
+		if err != nil {
+			log.Error("error fetching workflow run attempt jobs", "runId", run.Id,
+				"attempt", attempt, "err", err)
+			return runAttemptFetchedMsg{runId: run.Id, latest: latest.RunAttempt, err: err}
+		}
+
+		jobs := make([]data.WorkflowJob, 0, len(jobsResp.Jobs))
+		for _, job := range jobsResp.Jobs {
+			jobs = append(jobs, convertJobResponseToWorkflowJob(job, jobRelatedRun{
+				name:      run.Name,
+				event:     run.Event,
+				runNumber: run.RunNumber,
+			}))
+		}
+		data.SortJobs(jobs)
+
+		shown := convertRunResponseToWorkflowRun(attemptRun, api.WorkflowRunJobsResponse{})
+		shown.Id, shown.Name, shown.Jobs = run.Id, run.Name, jobs
+		return runAttemptFetchedMsg{
+			runId:   run.Id,
+			attempt: attempt,
+			latest:  latest.RunAttempt,
+			run:     shown,
+			jobs:    jobs,
+		}
+	}
+}
+
+func (m *model) onRunAttemptFetched(msg runAttemptFetchedMsg) []tea.Cmd {
+	ri := m.getRunItemById(msg.runId)
+	if ri == nil {
+		return nil
+	}
+
+	ri.loadingJobs = false
+	ri.attempts = max(ri.attempts, msg.latest)
+	if msg.err != nil {
+		return m.updateLists()
+	}
+
+	jobs := make([]*jobItem, 0, len(msg.jobs))
+	for _, job := range msg.jobs {
+		if ji := m.getJobItemById(job.Id); ji != nil {
+			jobs = append(jobs, ji)
+			continue
+		}
+
+		ji := NewJobItem(job, m.styles)
+		// the steps of previous attempts aren't part of the run's check suite
+		ji.loadingSteps = false
+		for _, step := range job.Steps {
+			si := NewStepItem(step, job.Link, m.styles)
+			ji.steps = append(ji.steps, &si)
+		}
+		jobs = append(jobs, &ji)
+	}
+
+	log.Info("showing run attempt", "runId", ri.run.Id, "attempt", msg.attempt, "latest", msg.latest)
+	ri.jobsItems = jobs
+	ri.attempt = msg.attempt
+	ri.attemptRun = &msg.run
+	if msg.attempt >= ri.latestAttempt() {
+		// back to the latest attempt, whose jobs are refreshed as usual
+		ri.attempt = 0
+		ri.attemptRun = nil
+		ri.run.Jobs = msg.jobs
+		ri.lastFetchJobs = time.Now()
+	}
+
+	if curr := m.getSelectedRunItem(); curr == nil || curr.run.Id != ri.run.Id {
+		return nil
+	}
+
+	m.jobsList.ResetSelected()
+	cmds := m.updateLists()
+	cmds = append(cmds, m.onJobChanged()...)
+	return cmds
+}
+
+// viewJobAttempt renders the attempt of the run the selected job's logs came from,
+// highlighted when it isn't the latest one
+func (m *model) viewJobAttempt() string {
+	ji := m.getSelectedJobItem()
+	if ji == nil || ji.job.RunAttempt == 0 {
+		return ""
+	}
+
+	latest := ji.job.RunAttempt
+	if ri := m.getSelectedRunItem(); ri != nil && !m.flat {
+		latest = max(latest, ri.latestAttempt())
+	}
+	if latest < 2 {
+		return ""
+	}
+
+	s := m.styles.faintFgStyle
+	if ji.job.RunAttempt < latest {
+		s = lipgloss.NewStyle().Foreground(m.styles.colors.warnColor)
+	}
+	return s.Render(fmt.Sprintf("attempt %d/%d", ji.job.RunAttempt, latest))
+}
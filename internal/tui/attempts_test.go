@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestCyclingRunAttempts(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:         "1",
+		Name:       "test",
+		Event:      "push",
+		RunAttempt: 2,
+		Jobs: []data.WorkflowJob{
+			{Id: "21", Name: "unit", State: api.StatusCompleted, Conclusion: api.ConclusionSuccess,
+				Kind: data.JobKindGithubActions, RunAttempt: 2},
+		},
+	}}})
+	m = updated.(model)
+
+	ri := m.getSelectedRunItem()
+	if desc := ri.Description(); !strings.Contains(desc, "attempt 2/2") {
+		t.Errorf("expected the run to show its latest attempt, got %q", desc)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: '[', Text: "["})
+	m = updated.(model)
+	if !m.getSelectedRunItem().loadingJobs {
+		t.Fatal("expected the previous attempt's jobs to be fetched")
+	}
+
+	updated, _ = m.Update(runAttemptFetchedMsg{
+		runId:   "1",
+		attempt: 1,
+		latest:  2,
+		run:     data.WorkflowRun{Id: "1", Name: "test", Bucket: data.CheckBucketFail, RunAttempt: 1},
+		jobs: []data.WorkflowJob{
+			{Id: "11", Name: "unit", State: api.StatusCompleted, Conclusion: api.ConclusionFailure,
+				Kind: data.JobKindGithubActions, RunAttempt: 1,
+				Steps: []api.Step{{Name: "Run tests", Number: 1, Conclusion: api.ConclusionFailure}}},
+		},
+	})
+	m = updated.(model)
+	ri = m.getSelectedRunItem()
+	if ri.attempt != 1 || ri.ShouldFetchJobs() {
+		t.Errorf("expected the run to stay on its first attempt, got attempt %d", ri.attempt)
+	}
+	if desc := ri.Description(); !strings.Contains(desc, "attempt 1/2") {
+		t.Errorf("expected the run to show the attempt being viewed, got %q", desc)
+	}
+	if run := ri.shownRun(); run.Bucket != data.CheckBucketFail {
+		t.Errorf("expected the run's status to be the one of the attempt being viewed, got %s", run.Bucket)
+	}
+	ji := m.getSelectedJobItem()
+	if ji == nil || ji.job.Id != "11" || len(ji.steps) != 1 {
+		t.Fatalf("expected the first attempt's job to be selected with its steps, got %+v", ji)
+	}
+
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: []data.LogsWithTime{{Log: "FAIL flaky_test"}}})
+	m = updated.(model)
+	if view := ansi.Strip(m.viewLogs()); !strings.Contains(view, "attempt 1/2") {
+		t.Errorf("expected the logs title to show the attempt they came from, got %q", view)
+	}
+
+	// polling keeps the previous attempt shown
+	updated, _ = m.Update(runJobsFetchedMsg{runId: "1", jobs: []data.WorkflowJob{
+		{Id: "21", Name: "unit", State: api.StatusCompleted, Kind: data.JobKindGithubActions, RunAttempt: 2},
+	}})
+	m = updated.(model)
+	if ji := m.getSelectedJobItem(); ji == nil || ji.job.Id != "11" {
+		t.Errorf("expected the first attempt's jobs to stay shown, got %+v", ji)
+	}
+
+	updated, _ = m.Update(runAttemptFetchedMsg{runId: "1", attempt: 2, latest: 2, jobs: []data.WorkflowJob{
+		{Id: "21", Name: "unit", State: api.StatusCompleted, Conclusion: api.ConclusionSuccess,
+			Kind: data.JobKindGithubActions, RunAttempt: 2},
+	}})
+	m = updated.(model)
+	if ri := m.getSelectedRunItem(); ri.attempt != 0 || ri.shownRun() != ri.run {
+		t.Errorf("expected to be back on the latest attempt, got %d", ri.attempt)
+	}
+	if ji := m.getSelectedJobItem(); ji == nil || ji.job.Id != "21" {
+		t.Errorf("expected the latest attempt's job to be selected, got %+v", ji)
+	}
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestCancelRunUpdatesOptimistically(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:     "1",
+		Name:   "test",
+		Bucket: data.CheckBucketPending,
+		Jobs: []data.WorkflowJob{
+			{Id: "10", Name: "lint", Bucket: data.CheckBucketPass, Kind: data.JobKindGithubActions},
+			{
+				Id:     "11",
+				Name:   "unit",
+				State:  api.StatusInProgress,
+				Bucket: data.CheckBucketPending,
+				Kind:   data.JobKindGithubActions,
+			},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl})
+	m = updated.(model)
+
+	if run := m.getSelectedRun(); run.Bucket != data.CheckBucketCancel {
+		t.Errorf("expected the run to be marked as canceled, got %s", run.Bucket)
+	}
+	if ji := m.getJobItemById("11"); ji.job.Bucket != data.CheckBucketCancel ||
+		ji.job.State != api.StatusCompleted {
+		t.Errorf("expected the running job to be marked as canceled, got %+v", ji.job)
+	}
+	if ji := m.getJobItemById("10"); ji.job.Bucket != data.CheckBucketPass {
+		t.Errorf("expected the finished job to be left as is, got %s", ji.job.Bucket)
+	}
+}
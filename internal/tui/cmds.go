@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -12,7 +13,6 @@ import (
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/log/v2"
-	"github.com/cli/go-gh"
 	"github.com/cli/go-gh/pkg/browser"
 
 	"github.com/dlvhdr/gh-enhance/internal/api"
@@ -22,6 +22,7 @@ import (
 )
 
 type workflowRunsFetchedMsg struct {
+	cursor    string // the cursor the page was fetched with, empty for the first page
 	pr        api.PRWithChecks
 	runs      []data.WorkflowRun
 	rateLimit api.RateLimit
@@ -42,7 +43,13 @@ func (m *model) makeInitialGetPRChecksCmd(prNumber string) tea.Cmd {
 
 func (m *model) makeInitialGetRepoChecksCmd() tea.Cmd {
 	return func() tea.Msg {
-		return m.fetchRepoChecksWithCursor("")
+		return m.fetchRepoChecksPage(1)
+	}
+}
+
+func (m *model) makeGetNextPageRepoChecksCmd(page int) tea.Cmd {
+	return func() tea.Msg {
+		return m.fetchRepoChecksPage(page)
 	}
 }
 
@@ -92,7 +99,7 @@ func (m *model) makeFetchRepoChecksWithInterval() tea.Cmd {
 		}
 
 		log.Info("refreshing repo checks on interval", "time", t)
-		return repoModeIntervalFetchMsg{msg: m.fetchRepoChecksWithCursor("")}
+		return repoModeIntervalFetchMsg{msg: m.fetchRepoChecksPage(1)}
 	})
 }
 
@@ -113,7 +120,7 @@ func (m model) fetchPRChecksWithCursor(prNumber string, cursor string) tea.Msg {
 	resp, err := m.client.FetchPRCheckRuns(m.repo, prNumber, cursor)
 	if err != nil {
 		log.Error("error fetching pr checks", "err", err)
-		return workflowRunsFetchedMsg{err: err, rateLimit: resp.RateLimit}
+		return workflowRunsFetchedMsg{cursor: cursor, err: err, rateLimit: resp.RateLimit}
 	}
 
 	if resp.Resource.PullRequest.Number == 0 {
@@ -122,8 +129,10 @@ func (m model) fetchPRChecksWithCursor(prNumber string, cursor string) tea.Msg {
 
 	nodes := resp.Resource.PullRequest.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes
 	runs := makeWorkflowRuns(nodes)
+	m.fetchRemainingPendingEnvironments(nodes, runs)
 
 	return workflowRunsFetchedMsg{
+		cursor:    cursor,
 		rateLimit: resp.RateLimit,
 		pr:        resp.Resource.PullRequest,
 		runs:      runs,
@@ -131,16 +140,18 @@ func (m model) fetchPRChecksWithCursor(prNumber string, cursor string) tea.Msg {
 }
 
 type repoModeRunsFetchedMsg struct {
-	Repo string
-	Runs []data.WorkflowRun
-	Err  error
+	Repo       string
+	Runs       []data.WorkflowRun
+	Page       int
+	TotalCount int
+	Err        error
 }
 
-func (m model) fetchRepoChecksWithCursor(cursor string) tea.Msg {
-	resp, err := m.client.FetchRepoWorkflowRuns(m.repo, cursor)
+func (m model) fetchRepoChecksPage(page int) tea.Msg {
+	resp, err := m.client.FetchRepoWorkflowRuns(m.repo, m.repoFilters, page)
 	if err != nil {
-		log.Error("error fetching repo checks", "err", err)
-		return repoModeRunsFetchedMsg{Err: err}
+		log.Error("error fetching repo checks", "page", page, "err", err)
+		return repoModeRunsFetchedMsg{Page: page, Err: err}
 	}
 
 	wfRuns := make([]data.WorkflowRun, 0)
@@ -158,7 +169,7 @@ func (m model) fetchRepoChecksWithCursor(cursor string) tea.Msg {
 					"err",
 					err,
 				)
-				return runModeFetchedMsg{err: err}
+				return repoModeRunsFetchedMsg{Page: page, Err: err}
 			}
 		}
 		run.Name = fmt.Sprintf("%s #%s", run.Name, strconv.Itoa(run.RunNumber))
@@ -167,8 +178,10 @@ func (m model) fetchRepoChecksWithCursor(cursor string) tea.Msg {
 	}
 
 	return repoModeRunsFetchedMsg{
-		Repo: m.repo,
-		Runs: wfRuns,
+		Repo:       m.repo,
+		Runs:       wfRuns,
+		Page:       page,
+		TotalCount: resp.TotalCount,
 	}
 }
 
@@ -215,10 +228,10 @@ func (m model) makeFetchWorkflowRunJobsCmd(run data.WorkflowRun) tea.Cmd {
 }
 
 type jobLogsFetchedMsg struct {
-	jobId  string
-	logs   []data.LogsWithTime
-	err    error
-	stderr string
+	jobId   string
+	logs    []data.LogsWithTime
+	partial bool // the job was still running when its logs were fetched
+	err     error
 }
 
 type checkRunOutputFetchedMsg struct {
@@ -260,19 +273,26 @@ func (m *model) makeFetchJobLogsCmd() tea.Cmd {
 		ji = j
 	}
 
-	if ji.isStatusInProgress() {
+	if ji.job.Kind == data.JobKindStatusContext {
+		return m.makeRenderStatusContextCmd(ji)
+	}
+
+	if ji.isStatusInProgress() && !ji.isLive() {
 		return nil
 	}
 
 	log.Info("fetching job logs", "job", ji.job.Name)
-	ji.loadingLogs = true
+	// keep showing the logs fetched while the job ran until its complete logs come in
+	ji.loadingLogs = len(ji.logs) == 0
+	ji.fetchingLogs = true
 	ji.initiatedLogsFetch = true
+	partial := ji.isLive()
 	return func() tea.Msg {
 		defer utils.TimeTrack(time.Now(), "fetching job logs")
 		if ji.job.Title != "" || ji.job.Kind == data.JobKindCheckRun ||
 			ji.job.Kind == data.JobKindExternal {
 			log.Debug("job is not JobKindGithubActions", "job", ji.job.Kind)
-			output, err := api.FetchCheckRunOutput(m.repo, ji.job.Id)
+			output, err := m.client.FetchCheckRunOutput(m.repo, ji.job.Id)
 			if err != nil {
 				log.Error("error fetching check run output", "link", ji.job.Link, "err", err)
 				return nil
@@ -300,36 +320,31 @@ func (m *model) makeFetchJobLogsCmd() tea.Cmd {
 
 		// Kind is JobKindGithubActions
 		log.Debug("job is JobKindGithubActions", "job", ji.job.Kind)
-		log.Debug(fmt.Sprintf("executing gh run view -R %s --log --job %s", m.repo, ji.job.Id))
-		jobLogsRes, stderr, err := gh.Exec("run", "view", "-R", m.repo, "--log", "--job", ji.job.Id)
+		jobLogs, err := m.client.FetchJobLogs(m.repo, ji.job.Id)
+		if partial && errors.Is(err, api.ErrLogsNotFound) {
+			err = fmt.Errorf("%w: %w", api.ErrLogsInProgress, err)
+		}
 		if err != nil {
-			// TODO: fetch with gh api
-			// if run is still in progress, gh CLI will not fetch the logs (why???)
-			// e.g.
-			// gh api \
-			//   -H "Accept: application/vnd.github+json" \
-			//   -H "X-GitHub-Api-Version: 2022-11-28" \
-			//   /repos/rapidsai/cuml/actions/jobs/46882393014/logs
 			log.Error("error fetching job logs", "kind", ji.job.Kind, "link",
-				ji.job.Link, "err", err, "stderr", stderr.String())
+				ji.job.Link, "err", err)
 			return jobLogsFetchedMsg{
-				jobId:  ji.job.Id,
-				err:    err,
-				stderr: stderr.String(),
+				jobId:   ji.job.Id,
+				partial: partial,
+				err:     err,
 			}
 		}
-		jobLogs := jobLogsRes.String()
 		log.Debug(
 			"success fetching job logs",
 			"link",
 			ji.job.Link,
 			"bytes",
-			len(jobLogsRes.Bytes()),
+			len(jobLogs),
 		)
 
 		return jobLogsFetchedMsg{
-			jobId: ji.job.Id,
-			logs:  parser.ParseJobLogs(jobLogs),
+			jobId:   ji.job.Id,
+			partial: partial,
+			logs:    parser.ParseJobLogs(jobLogs),
 		}
 	}
 }
@@ -339,7 +354,44 @@ type workflowRunStepsFetchedMsg struct {
 	data  api.WorkflowRunStepsQuery
 }
 
+// makeRenderStatusContextCmd renders the state and description of a commit status,
+// statuses have no logs or output on GitHub.
+func (m *model) makeRenderStatusContextCmd(ji *jobItem) tea.Cmd {
+	ji.initiatedLogsFetch = true
+	job := *ji.job
+	width := m.logsWidth()
+	return func() tea.Msg {
+		state := "pending"
+		if job.Conclusion != "" {
+			state = strings.ToLower(string(job.Conclusion))
+		}
+
+		text := fmt.Sprintf("# %s\n\n**State:** %s\n\n", job.Name, state)
+		if job.Title != "" {
+			text += job.Title + "\n\n"
+		}
+		if job.Link != "" {
+			text += fmt.Sprintf("Details: %s\n", job.Link)
+		}
+
+		renderedText, err := parser.ParseRunOutputMarkdown(text, width)
+		if err != nil {
+			log.Error("failed rendering as markdown", "link", job.Link, "err", err)
+			renderedText = text
+		}
+		return checkRunOutputFetchedMsg{
+			jobId:        job.Id,
+			title:        job.Name,
+			description:  job.Title,
+			renderedText: renderedText,
+		}
+	}
+}
+
 func (m *model) makeFetchWorkflowRunStepsCmd(runId string) tea.Cmd {
+	if runId == data.ExternalStatusesRunId {
+		return nil
+	}
 	return func() tea.Msg {
 		log.Debug("fetching all workflow run steps", "repo", m.repo, "runId", runId)
 		jobsWithStepsRes, err := m.client.FetchWorkflowRunSteps(m.repo, runId)
@@ -540,6 +592,7 @@ func convertJobResponseToWorkflowJob(
 		Bucket:      data.GetConclusionBucket(conclusion),
 		Kind:        data.JobKindGithubActions,
 		RunNumber:   relatedRun.runNumber,
+		RunAttempt:  j.RunAttempt,
 	}
 }
 
@@ -570,11 +623,14 @@ func convertRunResponseToWorkflowRun(
 		DisplayTitle: run.DisplayTitle,
 		Link:         run.HtmlUrl,
 		Workflow:     run.Name,
+		WorkflowId:   run.WorkflowId,
+		HeadSha:      run.HeadSha,
 		Event:        run.Event,
 		Jobs:         jobs,
 		Bucket:       data.GetConclusionBucket(runConclusion),
 		StartedAt:    run.RunStartedAt,
 		RunNumber:    run.RunNumber,
+		RunAttempt:   run.RunAttempt,
 		PRNumber:     prNumber,
 		Status:       run.Status,
 		Conclusion:   run.Conclusion,
@@ -603,7 +659,7 @@ func jobKind(cr api.CheckRun) data.JobKind {
 	var kind data.JobKind
 	if isGHA {
 		kind = data.JobKindGithubActions
-	} else if !strings.HasPrefix(cr.DetailsUrl, "https://github.com/") {
+	} else if !isSameHost(cr.DetailsUrl, cr.Url) {
 		kind = data.JobKindExternal
 	} else {
 		kind = data.JobKindCheckRun
@@ -612,6 +668,60 @@ func jobKind(cr api.CheckRun) data.JobKind {
 	return kind
 }
 
+// isSameHost reports whether both URLs point at the same host.
+// Check runs whose details URL leads elsewhere are reported by external CI providers.
+func isSameHost(a string, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return ua.Host != "" && strings.EqualFold(ua.Host, ub.Host)
+}
+
+// mergeRepoModeRuns merges a fetched page of repo runs with the runs already loaded.
+// The first page is refetched on every refresh, so its runs come first and any
+// older runs loaded from later pages are kept after them. Later pages are appended.
+func (m *model) mergeRepoModeRuns(msg repoModeRunsFetchedMsg) {
+	fetchedIds := make(map[string]bool, len(msg.Runs))
+	for _, run := range msg.Runs {
+		fetchedIds[run.Id] = true
+	}
+
+	runs := make([]data.WorkflowRun, 0, len(m.workflowRuns)+len(msg.Runs))
+	if msg.Page <= 1 {
+		runs = append(runs, msg.Runs...)
+		for _, run := range m.workflowRuns {
+			if !fetchedIds[run.Id] {
+				runs = append(runs, run)
+			}
+		}
+		m.workflowRuns = runs
+		return
+	}
+
+	fetched := make(map[string]data.WorkflowRun, len(msg.Runs))
+	for _, run := range msg.Runs {
+		fetched[run.Id] = run
+	}
+	for _, run := range m.workflowRuns {
+		if newRun, ok := fetched[run.Id]; ok {
+			run = newRun
+			delete(fetched, run.Id)
+		}
+		runs = append(runs, run)
+	}
+	for _, run := range msg.Runs {
+		if _, ok := fetched[run.Id]; ok {
+			runs = append(runs, run)
+		}
+	}
+	m.workflowRuns = runs
+}
+
 func (m *model) mergeWorkflowRuns(msg workflowRunsFetchedMsg) {
 	runsMap := make(map[int]data.WorkflowRun)
 
@@ -632,6 +742,9 @@ func (m *model) mergeWorkflowRuns(msg workflowRunsFetchedMsg) {
 
 		// run already exists, merge its jobs with the existing one
 		existing.Jobs = append(existing.Jobs, run.Jobs...)
+		if existing.IsExternalStatuses() {
+			existing.Bucket = externalStatusesBucket(existing.Jobs)
+		}
 		runsMap[run.RunNumber] = existing
 	}
 
@@ -679,9 +792,75 @@ func makeWorkflowRuns(nodes []api.ContextNode) []data.WorkflowRun {
 		runs = append(runs, run)
 	}
 
+	if statuses := filterForStatusContexts(nodes); len(statuses) > 0 {
+		runs = append(runs, makeExternalStatusesRun(statuses))
+	}
+
 	return runs
 }
 
+// externalStatusesRunNumber keeps the pseudo-run of the commit statuses apart from
+// the runs of check suites, as runs are merged by their number.
+const externalStatusesRunNumber = -1
+
+// makeExternalStatusesRun groups commit statuses under a pseudo-run, statuses aren't
+// part of a check suite.
+func makeExternalStatusesRun(statuses []api.StatusContext) data.WorkflowRun {
+	jobs := make([]data.WorkflowJob, 0, len(statuses))
+	for _, status := range statuses {
+		jobs = append(jobs, makeStatusContextJob(status))
+	}
+	data.SortJobs(jobs)
+
+	run := data.WorkflowRun{
+		Id:        data.ExternalStatusesRunId,
+		Name:      "External statuses",
+		Workflow:  "commit statuses",
+		Jobs:      jobs,
+		RunNumber: externalStatusesRunNumber,
+	}
+	run.Bucket = externalStatusesBucket(run.Jobs)
+	return run
+}
+
+func makeStatusContextJob(status api.StatusContext) data.WorkflowJob {
+	bucket := data.GetConclusionBucket(status.State)
+	job := data.WorkflowJob{
+		Id:        "status:" + status.Context,
+		Name:      status.Context,
+		Title:     status.Description,
+		Workflow:  "External statuses",
+		Link:      status.TargetUrl,
+		StartedAt: status.CreatedAt,
+		Bucket:    bucket,
+		Kind:      data.JobKindStatusContext,
+		RunNumber: externalStatusesRunNumber,
+	}
+	if bucket == data.CheckBucketPending {
+		job.State = api.StatusPending
+	} else {
+		job.State = api.StatusCompleted
+		job.Conclusion = status.State
+		job.CompletedAt = status.CreatedAt
+	}
+	return job
+}
+
+// externalStatusesBucket is the bucket of the pseudo-run: failed if a status failed,
+// pending while one is pending and passed otherwise.
+func externalStatusesBucket(jobs []data.WorkflowJob) data.CheckBucket {
+	bucket := data.CheckBucket(data.CheckBucketPass)
+	for _, job := range jobs {
+		switch job.Bucket {
+		case data.CheckBucketFail:
+			return data.CheckBucketFail
+		case data.CheckBucketPending:
+			bucket = data.CheckBucketPending
+		}
+	}
+	return bucket
+}
+
 func makeWorkflowRun(checkRun api.CheckRun) data.WorkflowRun {
 	wfName := workflowName(checkRun)
 	link := checkRun.CheckSuite.WorkflowRun.Url
@@ -718,11 +897,45 @@ func makeWorkflowRun(checkRun api.CheckRun) data.WorkflowRun {
 	return run
 }
 
+// fetchRemainingPendingEnvironments completes the environments of runs waiting on
+// more deployment reviews than fit in the first page of the GraphQL connection.
+// The REST endpoint lists all of them at once.
+func (m model) fetchRemainingPendingEnvironments(nodes []api.ContextNode, runs []data.WorkflowRun) {
+	fetched := make(map[string]bool)
+	for _, checkRun := range filterForCheckRuns(nodes) {
+		wfr := checkRun.CheckSuite.WorkflowRun
+		runId := strconv.Itoa(wfr.DatabaseId)
+		if !wfr.PendingDeploymentRequests.PageInfo.HasNextPage || fetched[runId] {
+			continue
+		}
+		fetched[runId] = true
+
+		deployments, err := m.client.FetchPendingDeployments(m.repo, runId)
+		if err != nil {
+			log.Error("error fetching pending deployments", "runId", runId, "err", err)
+			continue
+		}
+		envs := make([]string, 0, len(deployments))
+		for _, d := range deployments {
+			envs = append(envs, d.Environment.Name)
+		}
+
+		for i := range runs {
+			if runs[i].Id != runId {
+				continue
+			}
+			for j := range runs[i].Jobs {
+				runs[i].Jobs[j].PendingEnv = strings.Join(envs, ", ")
+			}
+		}
+	}
+}
+
 func makeWorkflowJob(checkRun api.CheckRun) data.WorkflowJob {
-	pendingEnv := ""
 	wfr := checkRun.CheckSuite.WorkflowRun
-	if len(wfr.PendingDeploymentRequests.Nodes) > 0 {
-		pendingEnv = wfr.PendingDeploymentRequests.Nodes[0].Environment.Name
+	pendingEnvs := make([]string, 0, len(wfr.PendingDeploymentRequests.Nodes))
+	for _, req := range wfr.PendingDeploymentRequests.Nodes {
+		pendingEnvs = append(pendingEnvs, req.Environment.Name)
 	}
 
 	kind := jobKind(checkRun)
@@ -733,7 +946,7 @@ func makeWorkflowJob(checkRun api.CheckRun) data.WorkflowJob {
 		Conclusion:  checkRun.Conclusion,
 		Name:        checkRun.Name,
 		Workflow:    wfr.Workflow.Name,
-		PendingEnv:  pendingEnv,
+		PendingEnv:  strings.Join(pendingEnvs, ", "),
 		Event:       wfr.Event,
 		Logs:        []data.LogsWithTime{},
 		Link:        checkRun.Url,
@@ -807,24 +1020,17 @@ type reRunJobMsg struct {
 	err   error
 }
 
-func (m *model) rerunJob(runId string, jobId string) []tea.Cmd {
-	log.Info("re-running job", "runId", runId, "jobId", jobId)
+func (m *model) rerunJob(runId string, jobId string, debug bool) []tea.Cmd {
+	log.Info("re-running job", "runId", runId, "jobId", jobId, "debug", debug)
 	cmds := make([]tea.Cmd, 0)
 	ri := m.getRunItemById(runId)
 	ji := m.getJobItemById(jobId)
-	if ri == nil && ji == nil {
+	if ji == nil {
 		return cmds
 	}
 
-	commits := m.prWithChecks.Commits.Nodes
-	if len(commits) > 0 {
-		commits[0].Commit.StatusCheckRollup.State = api.CommitStatePending
-	}
-	ji.job.Bucket = data.CheckBucketPending
-	ji.job.State = api.StatusPending
-	ji.job.StartedAt = time.Now()
-	ji.job.CompletedAt = time.Time{}
-	ji.steps = make([]*stepItem, 0)
+	m.markPRPending()
+	m.markJobPending(ji)
 	m.stepsList.ResetSelected()
 	m.stepsList.SetItems(make([]list.Item, 0))
 
@@ -832,7 +1038,7 @@ func (m *model) rerunJob(runId string, jobId string) []tea.Cmd {
 		cmds = append(cmds, ri.Tick())
 	}
 	cmds = append(cmds, ji.Tick(), m.inProgressSpinner.Tick, func() tea.Msg {
-		return reRunJobMsg{jobId: jobId, err: api.ReRunJob(m.repo, jobId)}
+		return reRunJobMsg{jobId: jobId, err: m.client.ReRunJob(m.repo, jobId, debug)}
 	})
 	return cmds
 }
@@ -842,30 +1048,147 @@ type reRunRunMsg struct {
 	err   error
 }
 
-func (m *model) rerunRun(runId string) []tea.Cmd {
+func (m *model) rerunRun(run *data.WorkflowRun, debug bool) []tea.Cmd {
+	log.Info("re-running run", "runId", run.Id, "debug", debug)
 	cmds := make([]tea.Cmd, 0)
-	ri := m.getRunItemById(runId)
-	if ri == nil {
-		return cmds
+
+	m.markPRPending()
+	run.Event = "manual rerun"
+	run.Bucket = data.CheckBucketPending
+	if ri := m.getRunItemById(run.Id); ri != nil && !m.flat {
+		ri.run.Jobs = make([]data.WorkflowJob, 0)
+		ri.jobsItems = make([]*jobItem, 0)
+		m.jobsList.SetItems(make([]list.Item, 0))
+		m.stepsList.SetItems(make([]list.Item, 0))
+		cmds = append(cmds, ri.Tick())
+	} else {
+		// the flat list has no run to empty, its checks are marked as pending instead
+		for _, ji := range m.jobItemsOfRun(run.Id) {
+			m.markJobPending(ji)
+			cmds = append(cmds, ji.Tick())
+		}
 	}
 
+	runId := run.Id
+	cmds = append(cmds, m.inProgressSpinner.Tick, func() tea.Msg {
+		return reRunRunMsg{runId: runId, err: m.client.ReRunRun(m.repo, runId, debug)}
+	})
+	return cmds
+}
+
+// rerunFailedJobs marks the failed and canceled jobs of a run as pending right away,
+// the jobs depending on them are picked up by the next fetch.
+func (m *model) rerunFailedJobs(run *data.WorkflowRun, debug bool) []tea.Cmd {
+	log.Info("re-running failed jobs", "runId", run.Id, "debug", debug)
+	cmds := make([]tea.Cmd, 0)
+
+	m.markPRPending()
+	run.Event = "manual rerun"
+	run.Bucket = data.CheckBucketPending
+	selected := m.getSelectedJobItem()
+	for _, ji := range m.jobItemsOfRun(run.Id) {
+		if ji.job.Bucket != data.CheckBucketFail && ji.job.Bucket != data.CheckBucketCancel {
+			continue
+		}
+		m.markJobPending(ji)
+		if ji == selected {
+			m.stepsList.ResetSelected()
+			m.stepsList.SetItems(make([]list.Item, 0))
+		}
+		cmds = append(cmds, ji.Tick())
+	}
+	if ri := m.getRunItemById(run.Id); ri != nil {
+		cmds = append(cmds, ri.Tick())
+	}
+
+	runId := run.Id
+	cmds = append(cmds, m.inProgressSpinner.Tick, func() tea.Msg {
+		return reRunRunMsg{runId: runId, err: m.client.ReRunFailedJobs(m.repo, runId, debug)}
+	})
+	return cmds
+}
+
+func (m *model) markPRPending() {
 	commits := m.prWithChecks.Commits.Nodes
 	if len(commits) > 0 {
 		commits[0].Commit.StatusCheckRollup.State = api.CommitStatePending
 	}
-	ri.run.Event = "manual rerun"
-	ri.run.Bucket = data.CheckBucketPending
-	ri.run.Jobs = make([]data.WorkflowJob, 0)
-	ri.jobsItems = make([]*jobItem, 0)
-	m.jobsList.SetItems(make([]list.Item, 0))
-	m.stepsList.SetItems(make([]list.Item, 0))
+}
+
+func (m *model) markJobPending(ji *jobItem) {
+	ji.job.Bucket = data.CheckBucketPending
+	ji.job.State = api.StatusPending
+	ji.job.Conclusion = ""
+	ji.job.StartedAt = time.Now()
+	ji.job.CompletedAt = time.Time{}
+	ji.steps = make([]*stepItem, 0)
+	ji.stepsLines = nil
+}
+
+type cancelRunMsg struct {
+	runId string
+	force bool
+	err   error
+}
 
-	cmds = append(cmds, ri.Tick(), func() tea.Msg {
-		return reRunRunMsg{runId: runId, err: api.ReRunRun(m.repo, runId)}
+// cancelRun asks GitHub to cancel the run, or to force cancel it when its jobs
+// ignore the cancellation. Its pending jobs show as canceled while the request is sent.
+func (m *model) cancelRun(run *data.WorkflowRun, force bool) []tea.Cmd {
+	cmds := make([]tea.Cmd, 0)
+	if run.Bucket != data.CheckBucketPending {
+		log.Info("not canceling a run that isn't in progress", "runId", run.Id)
+		return cmds
+	}
+	if !run.IsActionsRun() {
+		log.Info("not canceling the external statuses, they aren't a run on GitHub")
+		return cmds
+	}
+	log.Info("canceling run", "runId", run.Id, "force", force)
+
+	run.Bucket = data.CheckBucketCancel
+	for _, ji := range m.jobItemsOfRun(run.Id) {
+		if ji.job.Bucket != data.CheckBucketPending {
+			continue
+		}
+		ji.job.Bucket = data.CheckBucketCancel
+		ji.job.State = api.StatusCompleted
+		ji.job.Conclusion = api.ConclusionCancelled
+		ji.job.CompletedAt = time.Now()
+		cmds = append(cmds, ji.Tick())
+	}
+	if ri := m.getRunItemById(run.Id); ri != nil {
+		cmds = append(cmds, ri.Tick())
+	}
+
+	runId := run.Id
+	cmds = append(cmds, func() tea.Msg {
+		cancel := m.client.CancelRun
+		if force {
+			cancel = m.client.ForceCancelRun
+		}
+		return cancelRunMsg{runId: runId, force: force, err: cancel(m.repo, runId)}
 	})
 	return cmds
 }
 
+// reconcileDelay gives GitHub time to apply an action before the checks are refetched
+var reconcileDelay = time.Second * 3
+
+// makeReconcileCmd refetches the checks once, replacing the optimistic updates
+// made after an action with the state on GitHub.
+func (m *model) makeReconcileCmd() tea.Cmd {
+	return tea.Tick(reconcileDelay, func(time.Time) tea.Msg {
+		switch m.mode() {
+		case ModeRun:
+			return m.fetchRun()
+		case ModePR:
+			return m.fetchPRChecks(m.prNumber)
+		default:
+			return m.fetchRepoChecksPage(1)
+		}
+	})
+}
+
 type prFetchedMsg struct {
 	pr  api.PR
 	err error
@@ -898,6 +1221,17 @@ func filterForCheckRuns(nodes []api.ContextNode) []api.CheckRun {
 	return checkRuns
 }
 
+func filterForStatusContexts(nodes []api.ContextNode) []api.StatusContext {
+	statuses := make([]api.StatusContext, 0)
+	for _, node := range nodes {
+		if node.Typename != "StatusContext" {
+			continue
+		}
+		statuses = append(statuses, node.StatusContext)
+	}
+	return statuses
+}
+
 func (m *model) nextPane() pane {
 	showSteps := m.shouldShowSteps()
 	switch m.focusedPane {
@@ -909,19 +1243,18 @@ func (m *model) nextPane() pane {
 			return PaneSteps
 		}
 
-	case PaneSteps:
-		return PaneLogs
-
 	case PaneChecks:
 		if showSteps {
 			return PaneSteps
 		}
-		return PaneLogs
 
 	case PaneLogs:
 		return PaneLogs
 	}
 
+	if m.shouldShowAnnotations() && m.focusedPane != PaneAnnotations {
+		return PaneAnnotations
+	}
 	return PaneLogs
 }
 
@@ -944,9 +1277,21 @@ func (m *model) previousPane() pane {
 		return PaneChecks
 
 	case PaneLogs:
+		if m.shouldShowAnnotations() {
+			return PaneAnnotations
+		}
+		if showSteps {
+			return PaneSteps
+		}
+		return PaneJobs
+
+	case PaneAnnotations:
 		if showSteps {
 			return PaneSteps
 		}
+		if m.flat {
+			return PaneChecks
+		}
 		return PaneJobs
 	}
 
@@ -27,10 +27,13 @@ const (
 	FailureIcon  = "󰅙"
 	SuccessIcon  = ""
 	WarningIcon  = ""
+	NoticeIcon   = ""
 	MergedIcon   = ""
 	DraftIcon    = ""
 	OpenIcon     = ""
 	ClosedIcon   = ""
+	FilterIcon   = ""
+	TimeIcon     = ""
 
 	AsciiSkippedIcon = `
     ,---_   
@@ -48,11 +51,12 @@ const (
 		:---:
 		`
 
-	Separator    = "│"
-	ExpandSymbol = "▶"
-	ListSymbol   = "≡"
-	Ellipsis     = "…"
-	ParentLogo   = "〓"
+	Separator      = "│"
+	ExpandSymbol   = "▶"
+	CollapseSymbol = "▼"
+	ListSymbol     = "≡"
+	Ellipsis       = "…"
+	ParentLogo     = "〓"
 
 	Logo = `▐▔▔▐▚ ▌▐ ▌▐▔▌▐▚ ▌▐▔▔▐▔▔
 ▐▛▁▐ ▚▌▐▔▌▐▔▌▐ ▚▌▐▁▁▐▛▁`
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/log/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// deepLink is the job, step and log line to land on when starting, e.g. from a
+// URL copied from GitHub. The job, its steps and its logs are fetched at different
+// times, so it's applied in stages as they arrive.
+type deepLink struct {
+	jobID        string
+	step         int // the number of the step, 0 for none
+	line         int // the log line, relative to the step when one is set, 0 for none
+	jobSelected  bool
+	stepSelected bool
+}
+
+func newDeepLink(opts ModelOpts) *deepLink {
+	if opts.JobID == "" {
+		return nil
+	}
+	return &deepLink{jobID: opts.JobID, step: opts.Step, line: opts.Line}
+}
+
+// applyDeepLink selects and focuses whatever part of the deep link can be applied
+// with the data fetched so far. It's dropped once the user selects another job.
+func (m *model) applyDeepLink() []tea.Cmd {
+	dl := m.deepLink
+	if dl == nil {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, 0)
+	if !dl.jobSelected {
+		selected, selectCmds := m.selectJobById(dl.jobID)
+		if !selected {
+			return nil
+		}
+		log.Info("deep link job selected", "jobId", dl.jobID)
+		cmds = append(cmds, selectCmds...)
+		dl.jobSelected = true
+		m.focusPane(PaneJobs)
+	}
+
+	ji := m.getSelectedJobItem()
+	if ji == nil || ji.job.Id != dl.jobID {
+		m.deepLink = nil
+		return cmds
+	}
+
+	if dl.step > 0 && !dl.stepSelected {
+		if !m.selectStepByNumber(dl.step) {
+			return cmds
+		}
+		dl.stepSelected = true
+		m.onStepChanged()
+		if m.shouldShowSteps() {
+			m.focusPane(PaneSteps)
+		}
+	}
+
+	if dl.line == 0 {
+		m.deepLink = nil
+		return cmds
+	}
+
+	if len(ji.renderedLogs) == 0 {
+		return cmds
+	}
+
+	idx := dl.line - 1
+	if r, ok := ji.stepsLogs()[dl.step]; ok && dl.step > 0 {
+		idx += r.start
+	}
+	m.goToLogLine(ji, min(idx, len(ji.renderedLogs)-1))
+	m.focusPane(PaneLogs)
+	m.deepLink = nil
+
+	return cmds
+}
+
+func (m *model) selectJobById(jobId string) (bool, []tea.Cmd) {
+	if m.flat {
+		for i, item := range m.checksList.Items() {
+			if item.(*checkItem).job.Id == jobId {
+				m.checksList.Select(i)
+				return true, m.onCheckChanged()
+			}
+		}
+		return false, nil
+	}
+
+	for i, item := range m.runsList.Items() {
+		ri := item.(*runItem)
+		for _, ji := range ri.jobsItems {
+			if ji.job.Id != jobId {
+				continue
+			}
+
+			m.runsList.Select(i)
+			cmds := m.onRunChanged()
+			for j, job := range m.jobsList.Items() {
+				if job.(*jobItem).job.Id == jobId {
+					m.jobsList.Select(j)
+					cmds = append(cmds, m.onJobChanged()...)
+					break
+				}
+			}
+			return true, cmds
+		}
+	}
+	return false, nil
+}
+
+func (m *model) selectStepByNumber(number int) bool {
+	for i, item := range m.stepsList.Items() {
+		if item.(*stepItem).step.Number == number {
+			m.stepsList.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// goToLogLine scrolls the logs to a line, keeping a few lines above it in view,
+// and highlights it.
+func (m *model) goToLogLine(ji *jobItem, idx int) {
+	m.followLogs = false
+	row := m.revealLogLine(ji, idx)
+	m.logsViewport.SetYOffset(row - 3)
+
+	// highlights are byte ranges of the content without styles
+	start := 0
+	for _, line := range ji.shownLogs[:row] {
+		start += len(ansi.Strip(line)) + 1
+	}
+	line := ansi.Strip(ji.shownLogs[row])
+	if end := start + len(strings.TrimRight(line, " ")); end > start {
+		m.logsViewport.SetHighlights([][]int{{start, end}})
+	}
+}
+
+func (m *model) focusPane(p pane) {
+	if m.flat && p == PaneJobs {
+		p = PaneChecks
+	}
+	m.focusedPane = p
+	m.zoomedPane = nil
+	m.setFocusedPaneStyles()
+}
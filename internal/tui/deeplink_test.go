@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestDeepLinkToStepLine(t *testing.T) {
+	started := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1", JobID: "11", Step: 2, Line: 2})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "10", Name: "lint", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+			{Id: "11", Name: "unit", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+	if ji := m.getSelectedJobItem(); ji == nil || ji.job.Id != "11" {
+		t.Fatalf("expected the linked job to be selected, got %+v", ji)
+	}
+	if m.focusedPane != PaneJobs {
+		t.Errorf("expected the jobs pane to be focused, got %d", m.focusedPane)
+	}
+
+	steps := api.WorkflowRunStepsQuery{}
+	check := api.CheckRunWithSteps{DatabaseId: 11}
+	check.Steps.Nodes = []api.Step{
+		{Number: 1, Name: "Set up job", StartedAt: started},
+		{Number: 2, Name: "Run tests", StartedAt: started.Add(2 * time.Second)},
+	}
+	steps.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes = []api.CheckRunWithSteps{check}
+	updated, _ = m.Update(workflowRunStepsFetchedMsg{runId: "1", data: steps})
+	m = updated.(model)
+	if m.stepsList.Index() != 1 {
+		t.Errorf("expected the linked step to be selected, got %d", m.stepsList.Index())
+	}
+
+	logs := make([]data.LogsWithTime, 0)
+	for i := range 100 {
+		logs = append(logs, data.LogsWithTime{
+			Log:  "line " + strconv.Itoa(i),
+			Time: started.Add(time.Duration(i) * 200 * time.Millisecond),
+		})
+	}
+	logs[10] = data.LogsWithTime{Log: "##[group]Run tests", Kind: data.LogKindStepStart, Depth: 1}
+	logs[11].Kind = data.LogKindGroupEnd
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: logs})
+	m = updated.(model)
+
+	if m.focusedPane != PaneLogs {
+		t.Errorf("expected the logs pane to be focused, got %d", m.focusedPane)
+	}
+	if m.deepLink != nil {
+		t.Error("expected the deep link to be fully applied")
+	}
+	// step 2 starts at line 10, its second line is line 11
+	if got := m.logsViewport.YOffset(); got != 11-3 {
+		t.Errorf("expected the logs to be scrolled to line 11, got offset %d", got)
+	}
+}
@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// deploymentReview approves or rejects the environments a run is waiting on.
+// The review is submitted by the caller.
+type deploymentReview struct {
+	runId       string
+	runName     string
+	owner       string // the repo's owner, teams are shown as @owner/team
+	deployments []api.PendingDeployment
+	selected    map[int64]bool
+	cursor      int
+	comment     textinput.Model
+	loading     bool
+	submitting  bool
+	err         error
+	styles      styles
+}
+
+func newDeploymentReview(s styles, repo string, run *data.WorkflowRun) *deploymentReview {
+	owner, _, _ := strings.Cut(repo, "/")
+	return &deploymentReview{
+		runId:    run.Id,
+		runName:  run.Name,
+		owner:    owner,
+		selected: map[int64]bool{},
+		comment:  newFormInput(s, "optional"),
+		loading:  true,
+		styles:   s,
+	}
+}
+
+// setDeployments selects every environment the user is allowed to review
+func (r *deploymentReview) setDeployments(deployments []api.PendingDeployment, err error) {
+	r.loading = false
+	r.err = err
+	r.deployments = deployments
+	for _, d := range deployments {
+		if d.CurrentUserCanApprove {
+			r.selected[d.Environment.Id] = true
+		}
+	}
+}
+
+// Update moves between the environments and the comment. It returns the state
+// to submit once the selected environments are approved or rejected.
+func (r *deploymentReview) Update(msg tea.KeyPressMsg) (tea.Cmd, api.DeploymentReviewState) {
+	if r.comment.Focused() {
+		if key.Matches(msg, nextFormFieldKey, prevFormFieldKey, submitFormKey) {
+			r.comment.Blur()
+			return nil, ""
+		}
+		var cmd tea.Cmd
+		r.comment, cmd = r.comment.Update(msg)
+		return cmd, ""
+	}
+
+	if r.loading || r.submitting || len(r.deployments) == 0 {
+		return nil, ""
+	}
+
+	switch {
+	case key.Matches(msg, nextRowKey):
+		r.cursor = min(r.cursor+1, len(r.deployments)-1)
+	case key.Matches(msg, prevRowKey):
+		r.cursor = max(r.cursor-1, 0)
+	case key.Matches(msg, nextFormFieldKey, prevFormFieldKey):
+		return r.comment.Focus(), ""
+	case key.Matches(msg, toggleKey):
+		d := r.deployments[r.cursor]
+		if d.CurrentUserCanApprove {
+			r.selected[d.Environment.Id] = !r.selected[d.Environment.Id]
+		}
+	case key.Matches(msg, approveDeploymentsKey, rejectDeploymentsKey):
+		if ids, _ := r.selectedEnvironments(); len(ids) == 0 {
+			r.err = errors.New("select an environment to review")
+			return nil, ""
+		}
+		r.err = nil
+		r.submitting = true
+		if key.Matches(msg, approveDeploymentsKey) {
+			return nil, api.DeploymentApproved
+		}
+		return nil, api.DeploymentRejected
+	}
+	return nil, ""
+}
+
+func (r *deploymentReview) selectedEnvironments() ([]int64, []string) {
+	ids := make([]int64, 0)
+	names := make([]string, 0)
+	for _, d := range r.deployments {
+		if r.selected[d.Environment.Id] {
+			ids = append(ids, d.Environment.Id)
+			names = append(names, d.Environment.Name)
+		}
+	}
+	return ids, names
+}
+
+func (r *deploymentReview) reviewers(d api.PendingDeployment) string {
+	reviewers := make([]string, 0, len(d.Reviewers))
+	for _, reviewer := range d.Reviewers {
+		if reviewer.Type == "Team" {
+			reviewers = append(reviewers, "@"+r.owner+"/"+reviewer.Reviewer.Slug)
+		} else {
+			reviewers = append(reviewers, "@"+reviewer.Reviewer.Login)
+		}
+	}
+	return strings.Join(reviewers, ", ")
+}
+
+func (r *deploymentReview) View() string {
+	labelStyle := lipgloss.NewStyle().Width(10).Foreground(r.styles.colors.faintColor)
+	focusedStyle := lipgloss.NewStyle().Foreground(r.styles.colors.focusedColor).Bold(true)
+
+	rows := []string{lipgloss.NewStyle().Bold(true).MarginBottom(1).Render(
+		"Review deployments of " + r.runName)}
+	switch {
+	case r.loading:
+		rows = append(rows, r.styles.faintFgStyle.Render("Fetching the pending deployments…"))
+	case len(r.deployments) == 0 && r.err == nil:
+		rows = append(rows, r.styles.faintFgStyle.Render("No deployments are waiting for a review"))
+	}
+
+	for i, d := range r.deployments {
+		check := "[ ]"
+		if r.selected[d.Environment.Id] {
+			check = "[x]"
+		}
+		row := check + " " + d.Environment.Name
+		if i == r.cursor && !r.comment.Focused() {
+			row = focusedStyle.Render("> " + row)
+		} else {
+			row = "  " + row
+		}
+		if d.WaitTimer > 0 {
+			row += r.styles.faintFgStyle.Render(fmt.Sprintf("  wait timer %s",
+				time.Duration(d.WaitTimer)*time.Minute))
+		}
+		rows = append(rows, row)
+
+		details := "reviewers: " + r.reviewers(d)
+		if len(d.Reviewers) == 0 {
+			details = "no required reviewers"
+		}
+		if !d.CurrentUserCanApprove {
+			details += " • you can't review this environment"
+		}
+		rows = append(rows, r.styles.faintFgStyle.PaddingLeft(6).Render(details))
+	}
+
+	commentLabel := labelStyle.Render("Comment")
+	if r.comment.Focused() {
+		commentLabel = focusedStyle.Width(10).Render("Comment")
+	}
+	rows = append(rows, lipgloss.NewStyle().MarginTop(1).Render(
+		lipgloss.JoinHorizontal(lipgloss.Top, commentLabel, r.comment.View())))
+
+	if r.err != nil {
+		rows = append(rows, lipgloss.NewStyle().
+			MarginTop(1).
+			Width(50).
+			Foreground(r.styles.colors.errorColor).
+			Render(r.err.Error()))
+	}
+
+	help := "space toggle • a approve • r reject • tab comment • esc cancel"
+	if r.submitting {
+		help = "submitting the review…"
+	}
+	rows = append(rows, r.styles.faintFgStyle.MarginTop(1).Render(help))
+
+	return r.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+type pendingDeploymentsFetchedMsg struct {
+	runId       string
+	deployments []api.PendingDeployment
+	err         error
+}
+
+func (m *model) makeFetchPendingDeploymentsCmd(runId string) tea.Cmd {
+	return func() tea.Msg {
+		deployments, err := m.client.FetchPendingDeployments(m.repo, runId)
+		if err != nil {
+			log.Error("error fetching pending deployments", "runId", runId, "err", err)
+		}
+		return pendingDeploymentsFetchedMsg{runId: runId, deployments: deployments, err: err}
+	}
+}
+
+type deploymentsReviewedMsg struct {
+	runId        string
+	environments []string
+	state        api.DeploymentReviewState
+	err          error
+}
+
+func (m *model) makeReviewDeploymentsCmd(r *deploymentReview, state api.DeploymentReviewState) tea.Cmd {
+	ids, names := r.selectedEnvironments()
+	runId := r.runId
+	comment := strings.TrimSpace(r.comment.Value())
+	log.Info("reviewing deployments", "runId", runId, "environments", names, "state", state)
+	return func() tea.Msg {
+		err := m.client.ReviewPendingDeployments(m.repo, runId, ids, state, comment)
+		return deploymentsReviewedMsg{runId: runId, environments: names, state: state, err: err}
+	}
+}
+
+// onDeploymentsReviewed applies a review to the jobs waiting on its environments.
+// A rejection fails them along with the run, an approval drops the reviewed
+// environments and queues the jobs left with none.
+func (m *model) onDeploymentsReviewed(msg deploymentsReviewedMsg) []tea.Cmd {
+	cmds := make([]tea.Cmd, 0)
+	rejected := msg.state == api.DeploymentRejected
+	for _, ji := range m.jobItemsOfRun(msg.runId) {
+		if ji.job.State != api.StatusWaiting || !waitsOnEnvironments(ji.job, msg.environments) {
+			continue
+		}
+
+		if rejected {
+			ji.job.PendingEnv = ""
+			ji.job.State = api.StatusCompleted
+			ji.job.Conclusion = api.ConclusionFailure
+			ji.job.Bucket = data.CheckBucketFail
+			ji.job.CompletedAt = time.Now()
+			cmds = append(cmds, ji.Tick())
+			continue
+		}
+
+		ji.job.PendingEnv = strings.Join(unreviewedEnvironments(ji.job, msg.environments), ", ")
+		if ji.job.PendingEnv == "" {
+			ji.job.State = api.StatusQueued
+		}
+		cmds = append(cmds, ji.Tick())
+	}
+
+	if rejected {
+		for i := range m.workflowRuns {
+			if m.workflowRuns[i].Id == msg.runId {
+				m.workflowRuns[i].Bucket = data.CheckBucketFail
+			}
+		}
+		if ri := m.getRunItemById(msg.runId); ri != nil {
+			ri.run.Bucket = data.CheckBucketFail
+			cmds = append(cmds, ri.Tick())
+		}
+	}
+
+	m.lastFetched = time.Now()
+	return append(cmds, m.makeRestartPollingCmd())
+}
+
+// unreviewedEnvironments returns the environments the job still waits on after the
+// reviewed ones were approved
+func unreviewedEnvironments(job *data.WorkflowJob, reviewed []string) []string {
+	remaining := make([]string, 0)
+	if job.PendingEnv == "" {
+		return remaining
+	}
+	for env := range strings.SplitSeq(job.PendingEnv, ", ") {
+		if !slices.Contains(reviewed, env) {
+			remaining = append(remaining, env)
+		}
+	}
+	return remaining
+}
+
+// waitsOnEnvironments reports whether a job waits on one of the environments.
+// The environments of jobs fetched through REST aren't known, so they match any.
+func waitsOnEnvironments(job *data.WorkflowJob, environments []string) bool {
+	if job.PendingEnv == "" {
+		return true
+	}
+	for env := range strings.SplitSeq(job.PendingEnv, ", ") {
+		if slices.Contains(environments, env) {
+			return true
+		}
+	}
+	return false
+}
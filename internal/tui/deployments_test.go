@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestApprovingPendingDeployments(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:     "1",
+		Name:   "release",
+		Bucket: data.CheckBucketPending,
+		Jobs: []data.WorkflowJob{
+			{
+				Id:         "10",
+				Name:       "deploy",
+				State:      api.StatusWaiting,
+				PendingEnv: "production",
+				Bucket:     data.CheckBucketPending,
+				Kind:       data.JobKindGithubActions,
+			},
+			{
+				Id:         "11",
+				Name:       "deploy-all",
+				State:      api.StatusWaiting,
+				PendingEnv: "production, docs",
+				Bucket:     data.CheckBucketPending,
+				Kind:       data.JobKindGithubActions,
+			},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'D', Text: "D"})
+	m = updated.(model)
+	if m.deploymentReview == nil {
+		t.Fatal("expected the deployment review to open")
+	}
+
+	deployments := make([]api.PendingDeployment, 2)
+	deployments[0].Environment.Id = 100
+	deployments[0].Environment.Name = "production"
+	deployments[0].CurrentUserCanApprove = true
+	deployments[1].Environment.Id = 200
+	deployments[1].Environment.Name = "docs"
+	updated, _ = m.Update(pendingDeploymentsFetchedMsg{runId: "1", deployments: deployments})
+	m = updated.(model)
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	m = updated.(model)
+	if cmd == nil || !m.deploymentReview.submitting {
+		t.Fatal("expected the review to be submitted")
+	}
+	if ids, _ := m.deploymentReview.selectedEnvironments(); !slices.Equal(ids, []int64{100}) {
+		t.Errorf("expected only the environment the user can review to be selected, got %v", ids)
+	}
+
+	updated, _ = m.Update(deploymentsReviewedMsg{
+		runId:        "1",
+		environments: []string{"production"},
+		state:        api.DeploymentApproved,
+	})
+	m = updated.(model)
+
+	if m.deploymentReview != nil {
+		t.Error("expected the deployment review to close")
+	}
+	if ji := m.getJobItemById("10"); ji.job.State != api.StatusQueued || ji.job.PendingEnv != "" {
+		t.Errorf("expected the job to be queued, got %+v", ji.job)
+	}
+	if ji := m.getJobItemById("11"); ji.job.State != api.StatusWaiting || ji.job.PendingEnv != "docs" {
+		t.Errorf("expected the job to keep waiting on the unreviewed environment, got %+v", ji.job)
+	}
+}
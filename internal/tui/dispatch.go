@@ -0,0 +1,489 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// maxPickerRows is the number of workflows shown at once by the workflow picker
+const maxPickerRows = 10
+
+// dispatchJumpTimeout is how long to wait for a dispatched run to show up
+// before giving up on selecting it
+var dispatchJumpTimeout = time.Minute * 2
+
+type dispatchField struct {
+	input   api.DispatchInput
+	text    textinput.Model // the value of string and number inputs
+	checked bool            // the value of boolean inputs
+	options []string        // the options of choice and environment inputs
+	choice  int
+}
+
+// hasText reports whether the input is typed in, which is the case for strings,
+// numbers and types GitHub may add later
+func (f *dispatchField) hasText() bool {
+	switch f.input.Type {
+	case api.DispatchInputBoolean, api.DispatchInputChoice, api.DispatchInputEnvironment:
+		return false
+	default:
+		return true
+	}
+}
+
+func (f *dispatchField) value() string {
+	switch f.input.Type {
+	case api.DispatchInputBoolean:
+		return strconv.FormatBool(f.checked)
+	case api.DispatchInputChoice, api.DispatchInputEnvironment:
+		if len(f.options) == 0 {
+			return ""
+		}
+		return f.options[f.choice]
+	default:
+		return strings.TrimSpace(f.text.Value())
+	}
+}
+
+// dispatchForm picks a workflow of the repo and fills in the inputs of its
+// workflow_dispatch trigger. The dispatch is submitted by the caller.
+type dispatchForm struct {
+	workflows     []api.Workflow
+	defaultBranch string
+	cursor        int
+	workflow      *api.Workflow // nil while picking a workflow
+	ref           textinput.Model
+	inputsRef     string // the ref the inputs were read from, they're read again when the ref changes
+	fields        []dispatchField
+	focused       int // 0 is the ref, the inputs follow
+	loading       bool
+	submitting    bool
+	notice        string
+	err           error
+	styles        styles
+}
+
+func newDispatchForm(s styles) *dispatchForm {
+	return &dispatchForm{loading: true, styles: s}
+}
+
+func (f *dispatchForm) setWorkflows(workflows []api.Workflow, defaultBranch string, err error) {
+	f.loading = false
+	f.err = err
+	f.defaultBranch = defaultBranch
+	f.workflows = slices.DeleteFunc(workflows, func(w api.Workflow) bool {
+		return w.State != "active"
+	})
+}
+
+func (f *dispatchForm) setInputs(inputs []api.DispatchInput, environments []string, err error) {
+	f.loading = false
+	if err != nil {
+		f.err = err
+		f.workflow = nil
+		return
+	}
+
+	// inputs read again for another ref keep the values given to them
+	prev := make(map[string]dispatchField, len(f.fields))
+	for _, field := range f.fields {
+		prev[field.input.Name] = field
+	}
+	if f.fields == nil {
+		f.ref = newFormInput(f.styles, "a branch or a tag")
+		f.ref.SetValue(f.inputsRef)
+	}
+
+	f.fields = make([]dispatchField, 0, len(inputs))
+	for _, input := range inputs {
+		field := dispatchField{input: input, checked: input.Default == "true"}
+		switch input.Type {
+		case api.DispatchInputChoice:
+			field.options = input.Options
+		case api.DispatchInputEnvironment:
+			field.options = environments
+		}
+		field.choice = max(0, slices.Index(field.options, input.Default))
+		if field.hasText() {
+			field.text = newFormInput(f.styles, input.Description)
+			field.text.SetValue(input.Default)
+		}
+		if p, ok := prev[input.Name]; ok && p.input.Type == input.Type {
+			field.checked = p.checked
+			field.choice = max(0, slices.Index(field.options, p.value()))
+			if field.hasText() {
+				field.text.SetValue(p.text.Value())
+			}
+		}
+		f.fields = append(f.fields, field)
+	}
+	f.focus(0)
+}
+
+// setReloadedInputs replaces the inputs with the ones of the workflow file at the
+// new ref. The form is kept on errors, e.g. when the ref doesn't exist.
+func (f *dispatchForm) setReloadedInputs(inputs []api.DispatchInput, environments []string, err error) {
+	if err != nil {
+		f.loading = false
+		f.inputsRef = ""
+		f.err = fmt.Errorf("failed reading the workflow's inputs at %s: %w", f.ref.Value(), err)
+		return
+	}
+	f.setInputs(inputs, environments, nil)
+	f.notice = "The inputs were read again from " + f.inputsRef + ", enter to run"
+}
+
+// Update picks a workflow or edits its inputs. It returns the picked workflow
+// once one is chosen, and whether the inputs should be dispatched.
+func (f *dispatchForm) Update(msg tea.KeyPressMsg) (tea.Cmd, *api.Workflow, bool) {
+	if f.loading || f.submitting {
+		return nil, nil, false
+	}
+
+	if f.workflow == nil {
+		switch {
+		case key.Matches(msg, nextRowKey):
+			f.cursor = min(f.cursor+1, len(f.workflows)-1)
+		case key.Matches(msg, prevRowKey):
+			f.cursor = max(f.cursor-1, 0)
+		case key.Matches(msg, submitFormKey) && len(f.workflows) > 0:
+			f.err = nil
+			f.loading = true
+			f.fields = nil
+			f.inputsRef = f.defaultBranch
+			f.workflow = &f.workflows[f.cursor]
+			return nil, f.workflow, false
+		}
+		return nil, nil, false
+	}
+
+	switch {
+	case key.Matches(msg, nextFormFieldKey):
+		return f.focus((f.focused + 1) % (len(f.fields) + 1)), nil, false
+	case key.Matches(msg, prevFormFieldKey):
+		return f.focus((f.focused + len(f.fields)) % (len(f.fields) + 1)), nil, false
+	case key.Matches(msg, submitFormKey):
+		if err := f.validate(); err != nil {
+			f.err = err
+			return nil, nil, false
+		}
+		// the inputs may differ at another ref, they're read again before running
+		f.notice = ""
+		if ref := strings.TrimSpace(f.ref.Value()); ref != f.inputsRef {
+			f.err = nil
+			f.loading = true
+			f.inputsRef = ref
+			return nil, f.workflow, false
+		}
+		f.err = nil
+		f.submitting = true
+		return nil, nil, true
+	}
+
+	if f.focused == 0 {
+		var cmd tea.Cmd
+		f.ref, cmd = f.ref.Update(msg)
+		return cmd, nil, false
+	}
+
+	field := &f.fields[f.focused-1]
+	switch {
+	case field.hasText():
+		var cmd tea.Cmd
+		field.text, cmd = field.text.Update(msg)
+		return cmd, nil, false
+	case field.input.Type == api.DispatchInputBoolean:
+		if key.Matches(msg, toggleKey, leftKey, rightKey) {
+			field.checked = !field.checked
+		}
+	case len(field.options) > 0:
+		if key.Matches(msg, toggleKey, rightKey) {
+			field.choice = (field.choice + 1) % len(field.options)
+		} else if key.Matches(msg, leftKey) {
+			field.choice = (field.choice - 1 + len(field.options)) % len(field.options)
+		}
+	}
+	return nil, nil, false
+}
+
+func (f *dispatchForm) focus(i int) tea.Cmd {
+	f.ref.Blur()
+	for j := range f.fields {
+		f.fields[j].text.Blur()
+	}
+
+	f.focused = i
+	if i == 0 {
+		return f.ref.Focus()
+	}
+	if field := &f.fields[i-1]; field.hasText() {
+		return field.text.Focus()
+	}
+	return nil
+}
+
+func (f *dispatchForm) validate() error {
+	if strings.TrimSpace(f.ref.Value()) == "" {
+		return errors.New("ref is required")
+	}
+	for _, field := range f.fields {
+		value := field.value()
+		if field.input.Required && value == "" {
+			return fmt.Errorf("%s is required", field.input.Name)
+		}
+		if field.input.Type == api.DispatchInputNumber && value != "" {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("%s must be a number", field.input.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Inputs returns the values of the inputs, leaving out empty ones so their
+// defaults are used.
+func (f *dispatchForm) Inputs() map[string]string {
+	inputs := make(map[string]string, len(f.fields))
+	for _, field := range f.fields {
+		if value := field.value(); value != "" {
+			inputs[field.input.Name] = value
+		}
+	}
+	return inputs
+}
+
+func (f *dispatchForm) View() string {
+	if f.workflow == nil {
+		return f.styles.popupStyle.Render(f.pickerView())
+	}
+
+	labelStyle := lipgloss.NewStyle().Width(16).Foreground(f.styles.colors.faintColor)
+	focusedLabelStyle := labelStyle.Foreground(f.styles.colors.focusedColor).Bold(true)
+	label := func(i int, name string, required bool) string {
+		if required {
+			name += "*"
+		}
+		if i == f.focused {
+			return focusedLabelStyle.Render(name)
+		}
+		return labelStyle.Render(name)
+	}
+
+	rows := []string{lipgloss.NewStyle().Bold(true).MarginBottom(1).Render("Run " + f.workflow.Name)}
+	if f.loading {
+		rows = append(rows, f.styles.faintFgStyle.Render("Fetching the workflow's inputs…"))
+		return f.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	}
+
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, label(0, "ref", true), f.ref.View()))
+	for i, field := range f.fields {
+		value := ""
+		switch {
+		case field.hasText():
+			value = field.text.View()
+		case field.input.Type == api.DispatchInputBoolean:
+			value = "[ ]"
+			if field.checked {
+				value = "[x]"
+			}
+		case len(field.options) == 0:
+			value = f.styles.faintFgStyle.Render("no options")
+		default:
+			value = "‹ " + field.value() + " ›"
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top,
+			label(i+1, field.input.Name, field.input.Required), value))
+	}
+
+	if f.focused > 0 {
+		if desc := f.fields[f.focused-1].input.Description; desc != "" {
+			rows = append(rows, f.styles.faintFgStyle.MarginTop(1).Width(50).Render(desc))
+		}
+	}
+
+	if f.notice != "" {
+		rows = append(rows, f.styles.faintFgStyle.MarginTop(1).Width(50).Render(f.notice))
+	}
+	if f.err != nil {
+		rows = append(rows, lipgloss.NewStyle().
+			MarginTop(1).
+			Width(50).
+			Foreground(f.styles.colors.errorColor).
+			Render(f.err.Error()))
+	}
+
+	help := "tab next • space/←/→ change • enter run • esc cancel"
+	if f.submitting {
+		help = "dispatching the workflow…"
+	}
+	rows = append(rows, f.styles.faintFgStyle.MarginTop(1).Render(help))
+
+	return f.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func (f *dispatchForm) pickerView() string {
+	rows := []string{lipgloss.NewStyle().Bold(true).MarginBottom(1).Render("Run a workflow")}
+	switch {
+	case f.loading:
+		rows = append(rows, f.styles.faintFgStyle.Render("Fetching the workflows…"))
+	case len(f.workflows) == 0 && f.err == nil:
+		rows = append(rows, f.styles.faintFgStyle.Render("The repo has no active workflows"))
+	}
+
+	start := max(0, f.cursor-maxPickerRows+1)
+	end := min(len(f.workflows), start+maxPickerRows)
+	for i, workflow := range f.workflows[start:end] {
+		row := workflow.Name + " " + f.styles.faintFgStyle.Render(workflow.Path)
+		if start+i == f.cursor {
+			row = lipgloss.NewStyle().Foreground(f.styles.colors.focusedColor).Bold(true).
+				Render("> "+workflow.Name) + " " + f.styles.faintFgStyle.Render(workflow.Path)
+		} else {
+			row = "  " + row
+		}
+		rows = append(rows, row)
+	}
+
+	if f.err != nil {
+		rows = append(rows, lipgloss.NewStyle().
+			MarginTop(1).
+			Width(50).
+			Foreground(f.styles.colors.errorColor).
+			Render(f.err.Error()))
+	}
+
+	rows = append(rows, f.styles.faintFgStyle.MarginTop(1).Render(
+		"j/k move • enter pick • esc cancel"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+type workflowsFetchedMsg struct {
+	workflows     []api.Workflow
+	defaultBranch string
+	err           error
+}
+
+func (m *model) makeFetchWorkflowsCmd() tea.Cmd {
+	return func() tea.Msg {
+		workflows, err := m.client.FetchWorkflows(m.repo)
+		if err != nil {
+			log.Error("error fetching workflows", "err", err)
+			return workflowsFetchedMsg{err: err}
+		}
+		branch, err := m.client.FetchDefaultBranch(m.repo)
+		if err != nil {
+			log.Error("error fetching the default branch", "err", err)
+		}
+		return workflowsFetchedMsg{workflows: workflows, defaultBranch: branch, err: err}
+	}
+}
+
+type dispatchInputsFetchedMsg struct {
+	workflowId   int64
+	ref          string
+	inputs       []api.DispatchInput
+	environments []string
+	err          error
+}
+
+// makeFetchDispatchInputsCmd reads the inputs of the workflow file at a ref, or at
+// the default branch when ref is empty
+func (m *model) makeFetchDispatchInputsCmd(workflow api.Workflow, ref string) tea.Cmd {
+	return func() tea.Msg {
+		msg := dispatchInputsFetchedMsg{workflowId: workflow.Id, ref: ref}
+		content, err := m.client.FetchFileContent(m.repo, workflow.Path, ref)
+		if err != nil {
+			log.Error("error fetching workflow file", "path", workflow.Path, "err", err)
+			msg.err = err
+			return msg
+		}
+
+		msg.inputs, msg.err = api.ParseDispatchInputs(content)
+		if msg.err != nil {
+			return msg
+		}
+
+		for _, input := range msg.inputs {
+			if input.Type == api.DispatchInputEnvironment {
+				msg.environments, msg.err = m.client.FetchEnvironments(m.repo)
+				break
+			}
+		}
+		return msg
+	}
+}
+
+type workflowDispatchedMsg struct {
+	workflowId int64
+	err        error
+}
+
+func (m *model) makeDispatchWorkflowCmd(f *dispatchForm) tea.Cmd {
+	workflowId := f.workflow.Id
+	ref := strings.TrimSpace(f.ref.Value())
+	inputs := f.Inputs()
+	log.Info("dispatching workflow", "workflowId", workflowId, "ref", ref, "inputs", inputs)
+	return func() tea.Msg {
+		err := m.client.DispatchWorkflow(m.repo, workflowId, ref, inputs)
+		return workflowDispatchedMsg{workflowId: workflowId, err: err}
+	}
+}
+
+// pendingDispatch is a dispatched workflow whose run didn't show up in the repo's runs yet
+type pendingDispatch struct {
+	workflowId  int64
+	at          time.Time
+	knownRunIds map[string]bool
+}
+
+func (m *model) newPendingDispatch(workflowId int64) *pendingDispatch {
+	known := make(map[string]bool, len(m.workflowRuns))
+	for _, run := range m.workflowRuns {
+		known[run.Id] = true
+	}
+	return &pendingDispatch{workflowId: workflowId, at: time.Now(), knownRunIds: known}
+}
+
+// selectDispatchedRun selects the run of the dispatched workflow once it's fetched
+func (m *model) selectDispatchedRun() []tea.Cmd {
+	d := m.pendingDispatch
+	if d == nil {
+		return nil
+	}
+	if time.Since(d.at) > dispatchJumpTimeout {
+		log.Info("dispatched run didn't show up, not selecting it", "workflowId", d.workflowId)
+		m.pendingDispatch = nil
+		return nil
+	}
+
+	for i, item := range m.runsList.Items() {
+		run := item.(*runItem).run
+		if !isDispatchedRun(*run, d) {
+			continue
+		}
+
+		log.Info("selecting dispatched run", "runId", run.Id)
+		m.pendingDispatch = nil
+		m.runsList.Select(i)
+		m.focusPane(PaneRuns)
+		return m.onRunChanged()
+	}
+	return nil
+}
+
+func isDispatchedRun(run data.WorkflowRun, d *pendingDispatch) bool {
+	return int64(run.WorkflowId) == d.workflowId && run.Event == "workflow_dispatch" &&
+		!d.knownRunIds[run.Id]
+}
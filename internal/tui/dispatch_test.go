@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"maps"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestDispatchingWorkflowSelectsItsRun(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "dlvhdr/gh-dash"})
+	updated, _ := m.Update(repoModeRunsFetchedMsg{Page: 1, Runs: []data.WorkflowRun{
+		{Id: "1", Name: "CI #1", WorkflowId: 7, Event: "push", Bucket: data.CheckBucketPass},
+	}})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	m = updated.(model)
+	if m.dispatchForm == nil {
+		t.Fatal("expected the workflow picker to open")
+	}
+	updated, _ = m.Update(workflowsFetchedMsg{defaultBranch: "main", workflows: []api.Workflow{
+		{Id: 6, Name: "Nightly", State: "disabled_manually"},
+		{Id: 7, Name: "Release", Path: ".github/workflows/release.yml", State: "active"},
+	}})
+	m = updated.(model)
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+	if cmd == nil || m.dispatchForm.workflow.Id != 7 {
+		t.Fatal("expected the active workflow to be picked")
+	}
+	updated, _ = m.Update(dispatchInputsFetchedMsg{workflowId: 7, ref: "main", inputs: []api.DispatchInput{
+		{Name: "version", Type: api.DispatchInputString, Required: true},
+		{Name: "level", Type: api.DispatchInputChoice, Default: "minor", Options: []string{
+			"patch", "minor", "major",
+		}},
+		{Name: "dry-run", Type: api.DispatchInputBoolean},
+	}})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+	if m.dispatchForm.err == nil || m.dispatchForm.submitting {
+		t.Fatal("expected the required input to be validated")
+	}
+
+	for _, msg := range []tea.KeyPressMsg{
+		{Code: tea.KeyTab},
+		{Code: '2', Text: "2"},
+		{Code: tea.KeyTab},
+		{Code: tea.KeyRight},
+		{Code: tea.KeyTab},
+		{Code: tea.KeySpace, Text: " "},
+	} {
+		updated, _ = m.Update(msg)
+		m = updated.(model)
+	}
+	want := map[string]string{"version": "2", "level": "major", "dry-run": "true"}
+	if got := m.dispatchForm.Inputs(); !maps.Equal(got, want) {
+		t.Errorf("expected the inputs %v, got %v", want, got)
+	}
+
+	// the inputs are read again from another ref before running on it
+	m.dispatchForm.ref.SetValue("release")
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+	if cmd == nil || m.dispatchForm.submitting || !m.dispatchForm.loading {
+		t.Fatal("expected the inputs to be read again for the new ref")
+	}
+	updated, _ = m.Update(dispatchInputsFetchedMsg{workflowId: 7, ref: "release", inputs: []api.DispatchInput{
+		{Name: "version", Type: api.DispatchInputString, Required: true},
+		{Name: "level", Type: api.DispatchInputChoice, Default: "minor", Options: []string{
+			"patch", "minor", "major",
+		}},
+		{Name: "dry-run", Type: api.DispatchInputBoolean},
+		{Name: "notes", Type: api.DispatchInputString, Default: "none"},
+	}})
+	m = updated.(model)
+	want["notes"] = "none"
+	if got := m.dispatchForm.Inputs(); !maps.Equal(got, want) {
+		t.Errorf("expected the given values to be kept, got %v", got)
+	}
+
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+	if cmd == nil || !m.dispatchForm.submitting {
+		t.Fatal("expected the workflow to be dispatched")
+	}
+
+	updated, _ = m.Update(workflowDispatchedMsg{workflowId: 7})
+	m = updated.(model)
+	if m.dispatchForm != nil || m.pendingDispatch == nil {
+		t.Fatal("expected the form to close and wait for the dispatched run")
+	}
+
+	updated, _ = m.Update(repoModeRunsFetchedMsg{Page: 1, Runs: []data.WorkflowRun{
+		{Id: "3", Name: "CI #3", WorkflowId: 8, Event: "push", Bucket: data.CheckBucketPending},
+		{Id: "2", Name: "Release #1", WorkflowId: 7, Event: "workflow_dispatch", Bucket: data.CheckBucketPending},
+		{Id: "1", Name: "CI #1", WorkflowId: 7, Event: "push", Bucket: data.CheckBucketPass},
+	}})
+	m = updated.(model)
+
+	if run := m.getSelectedRun(); run == nil || run.Id != "2" {
+		t.Errorf("expected the dispatched run to be selected, got %+v", run)
+	}
+	if m.pendingDispatch != nil {
+		t.Error("expected to stop waiting for the dispatched run")
+	}
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// FetchRuns fetches the runs of a PR, a run or a repo's first page of runs without
+// starting the tui. The runs are merged the same way the tui lists them, with the
+// jobs and steps of every run loaded.
+func FetchRuns(opts ModelOpts) ([]data.WorkflowRun, error) {
+	m := NewModel(opts)
+	return m.fetchRunsWithJobs()
+}
+
+func (m *model) fetchRunsWithJobs() ([]data.WorkflowRun, error) {
+	var runs []data.WorkflowRun
+	switch {
+	case m.prNumber != "":
+		prRuns, err := m.fetchWatchedRuns()
+		if err != nil {
+			return nil, err
+		}
+		runs = prRuns
+	case m.runID != "":
+		msg := m.fetchRun().(runModeFetchedMsg)
+		return msg.runs, msg.err
+	default:
+		msg := m.fetchRepoChecksPage(1).(repoModeRunsFetchedMsg)
+		if msg.Err != nil {
+			return nil, msg.Err
+		}
+		runs = msg.Runs
+	}
+
+	for i, run := range runs {
+		if len(run.Jobs) == 0 {
+			jobsMsg := m.makeFetchWorkflowRunJobsCmd(run)().(runJobsFetchedMsg)
+			if jobsMsg.err != nil {
+				return nil, jobsMsg.err
+			}
+			runs[i].Jobs = jobsMsg.jobs
+			continue
+		}
+
+		if err := m.fetchRunSteps(&runs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return runs, nil
+}
+
+// fetchRunSteps fills in the steps of the jobs of a PR's run, which the
+// check runs of the PR don't include.
+func (m *model) fetchRunSteps(run *data.WorkflowRun) error {
+	hasActionsJobs := false
+	for _, job := range run.Jobs {
+		if job.Kind == data.JobKindGithubActions && len(job.Steps) == 0 {
+			hasActionsJobs = true
+		}
+	}
+	if !hasActionsJobs {
+		return nil
+	}
+
+	res, err := m.client.FetchWorkflowRunSteps(m.repo, run.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, check := range res.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes {
+		for i, job := range run.Jobs {
+			if job.Id == fmt.Sprintf("%d", check.DatabaseId) {
+				run.Jobs[i].Steps = check.Steps.Nodes
+			}
+		}
+	}
+	return nil
+}
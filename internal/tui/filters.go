@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+)
+
+type filterField struct {
+	label string
+	input textinput.Model
+	get   func(f *api.RepoWorkflowRunsFilters) *string
+}
+
+// filtersForm edits the filters of repo mode. The runs are refetched once the form is applied.
+type filtersForm struct {
+	fields  []filterField
+	focused int
+	err     error
+	styles  styles
+}
+
+func newFiltersForm(s styles, filters api.RepoWorkflowRunsFilters) *filtersForm {
+	form := &filtersForm{styles: s}
+	for _, f := range []struct {
+		label       string
+		placeholder string
+		get         func(f *api.RepoWorkflowRunsFilters) *string
+	}{
+		{"Workflow", "ci.yml or a workflow ID", func(f *api.RepoWorkflowRunsFilters) *string {
+			return &f.Workflow
+		}},
+		{"Branch", "main", func(f *api.RepoWorkflowRunsFilters) *string { return &f.Branch }},
+		{"Actor", "a GitHub login", func(f *api.RepoWorkflowRunsFilters) *string { return &f.Actor }},
+		{"Event", "push, pull_request…", func(f *api.RepoWorkflowRunsFilters) *string {
+			return &f.Event
+		}},
+		{"Status", "failure, in_progress…", func(f *api.RepoWorkflowRunsFilters) *string {
+			return &f.Status
+		}},
+		{"Created", ">=2025-01-01", func(f *api.RepoWorkflowRunsFilters) *string {
+			return &f.Created
+		}},
+		{"Commit", "a full commit SHA", func(f *api.RepoWorkflowRunsFilters) *string {
+			return &f.HeadSHA
+		}},
+	} {
+		ti := newFormInput(s, f.placeholder)
+		ti.SetValue(*f.get(&filters))
+		form.fields = append(form.fields, filterField{label: f.label, input: ti, get: f.get})
+	}
+	form.fields[0].input.Focus()
+	return form
+}
+
+// newFormInput returns a text input styled for the fields of popups
+func newFormInput(s styles, placeholder string) textinput.Model {
+	ti := textinput.New()
+	ti.SetWidth(30)
+	ti.Prompt = ""
+	ti.Placeholder = placeholder
+	ti.SetStyles(textinput.Styles{
+		Cursor: textinput.CursorStyle{
+			Color: s.colors.faintColor,
+			Shape: tea.CursorBar,
+			Blink: false,
+		},
+		Focused: textinput.StyleState{
+			Text:        lipgloss.NewStyle(),
+			Placeholder: s.faintFgStyle,
+		},
+		Blurred: textinput.StyleState{
+			Text:        s.faintFgStyle,
+			Placeholder: s.faintFgStyle,
+		},
+	})
+	ti.SetVirtualCursor(true)
+	return ti
+}
+
+// Update handles moving between the fields and typing into the focused one.
+// Applying or canceling the form is handled by the caller.
+func (f *filtersForm) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, nextFormFieldKey):
+			return f.focus((f.focused + 1) % len(f.fields))
+		case key.Matches(msg, prevFormFieldKey):
+			return f.focus((f.focused - 1 + len(f.fields)) % len(f.fields))
+		}
+	}
+
+	var cmd tea.Cmd
+	f.fields[f.focused].input, cmd = f.fields[f.focused].input.Update(msg)
+	return cmd
+}
+
+func (f *filtersForm) focus(i int) tea.Cmd {
+	f.fields[f.focused].input.Blur()
+	f.focused = i
+	return f.fields[f.focused].input.Focus()
+}
+
+func (f *filtersForm) Value() api.RepoWorkflowRunsFilters {
+	filters := api.RepoWorkflowRunsFilters{}
+	for _, field := range f.fields {
+		*field.get(&filters) = strings.TrimSpace(field.input.Value())
+	}
+	return filters
+}
+
+func (f *filtersForm) View() string {
+	labelStyle := lipgloss.NewStyle().Width(10).Foreground(f.styles.colors.faintColor)
+	focusedLabelStyle := labelStyle.Foreground(f.styles.colors.focusedColor).Bold(true)
+
+	rows := []string{lipgloss.NewStyle().Bold(true).MarginBottom(1).Render("Filter runs")}
+	for i, field := range f.fields {
+		label := labelStyle.Render(field.label)
+		if i == f.focused {
+			label = focusedLabelStyle.Render(field.label)
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, label, field.input.View()))
+	}
+
+	if f.err != nil {
+		rows = append(rows, lipgloss.NewStyle().
+			MarginTop(1).
+			Width(40).
+			Foreground(f.styles.colors.errorColor).
+			Render(f.err.Error()))
+	}
+
+	rows = append(rows, f.styles.faintFgStyle.MarginTop(1).Render(
+		"tab next • enter apply • esc cancel"))
+
+	return f.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
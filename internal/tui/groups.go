@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// logGroup is a foldable group of log lines, from its ##[group] line to its ##[endgroup] line
+type logGroup struct {
+	start, end int
+	// ended is false while the group's ##[endgroup] wasn't written yet, it then ends at the last line
+	ended    bool
+	hasError bool
+}
+
+// size returns the number of lines hidden when the group is folded, without its end marker
+func (g logGroup) size() int {
+	if g.ended {
+		return g.end - g.start - 1
+	}
+	return g.end - g.start
+}
+
+// parseLogGroups returns the groups of the logs ordered by their start
+func parseLogGroups(logs []data.LogsWithTime) []logGroup {
+	groups := make([]logGroup, 0)
+	open := make([]int, 0)
+	for i, l := range logs {
+		switch l.Kind {
+		case data.LogKindGroupStart, data.LogKindStepStart:
+			groups = append(groups, logGroup{start: i})
+			open = append(open, len(groups)-1)
+		case data.LogKindGroupEnd:
+			if len(open) == 0 {
+				continue
+			}
+			groups[open[len(open)-1]].end = i
+			groups[open[len(open)-1]].ended = true
+			open = open[:len(open)-1]
+		case data.LogKindError:
+			for _, g := range open {
+				groups[g].hasError = true
+			}
+		}
+	}
+	for _, g := range open {
+		groups[g].end = len(logs) - 1
+	}
+	return groups
+}
+
+// isGroupFolded reports whether the group's lines are hidden. Groups start folded
+// unless they have errors or are still being written.
+func (ji *jobItem) isGroupFolded(g logGroup) bool {
+	if folded, ok := ji.foldedGroups[g.start]; ok {
+		return folded
+	}
+	return g.ended && !g.hasError
+}
+
+func (ji *jobItem) setGroupFolded(g logGroup, folded bool) {
+	if ji.foldedGroups == nil {
+		ji.foldedGroups = make(map[int]bool)
+	}
+	ji.foldedGroups[g.start] = folded
+}
+
+// innermostGroupAt returns the innermost group containing the line
+func (ji *jobItem) innermostGroupAt(line int) (logGroup, bool) {
+	for i := len(ji.groups) - 1; i >= 0; i-- {
+		if g := ji.groups[i]; g.start <= line && line <= g.end {
+			return g, true
+		}
+	}
+	return logGroup{}, false
+}
+
+// foldLogs computes the lines of the job's rendered logs shown in the viewport,
+// hiding the lines of folded groups behind their first line, and the lines of other
+// steps when showing the selected step only.
+func (m *model) foldLogs(ji *jobItem) {
+	ji.groups = parseLogGroups(ji.logs)
+	starts := make(map[int]logGroup, len(ji.groups))
+	for _, g := range ji.groups {
+		starts[g.start] = g
+	}
+
+	ji.shownLines = make([]int, 0, len(ji.renderedLogs))
+	ji.shownLogs = make([]string, 0, len(ji.renderedLogs))
+	ji.shownUnstyledLogs = make([]string, 0, len(ji.renderedLogs))
+	from, to := 0, len(ji.renderedLogs)
+	if r, ok := m.stepOnlyLogs(ji); ok {
+		from, to = r.start, min(r.end, to)
+	}
+	for i := from; i < to; i++ {
+		rendered, unstyled := ji.renderedLogs[i], ji.unstyledLogs[i]
+		g, isStart := starts[i]
+		if isStart && ji.isGroupFolded(g) {
+			hidden := fmt.Sprintf(" (%d lines)", g.size())
+			rendered += m.styles.faintFgStyle.Render(hidden)
+			unstyled += hidden
+		} else if isStart {
+			rendered = strings.Replace(rendered, ExpandSymbol, CollapseSymbol, 1)
+			unstyled = strings.Replace(unstyled, ExpandSymbol, CollapseSymbol, 1)
+		}
+
+		ji.shownLines = append(ji.shownLines, i)
+		ji.shownLogs = append(ji.shownLogs, rendered)
+		ji.shownUnstyledLogs = append(ji.shownUnstyledLogs, unstyled)
+		if isStart && ji.isGroupFolded(g) {
+			i = min(g.end, to-1)
+		}
+	}
+}
+
+// setJobLogsContent shows the unfolded lines of the job's logs in the viewport
+func (m *model) setJobLogsContent(ji *jobItem) {
+	m.foldLogs(ji)
+	m.logsViewport.LeftGutterFunc = m.newJobLogsGutter(ji)
+	m.logsViewport.SetContentLines(ji.shownLogs)
+}
+
+// setLogsContentLines shows lines that aren't the job's logs, e.g. a preview or summary
+func (m *model) setLogsContentLines(lines []string) {
+	m.logsViewport.LeftGutterFunc = newLogsGutter(m.styles, nil)
+	m.logsViewport.SetContentLines(lines)
+}
+
+// newLogsGutter numbers the lines of the logs viewport. lineNumbers are the indices of the
+// lines in the full logs when some are hidden, nil numbers the lines in order.
+func newLogsGutter(s styles, lineNumbers []int) viewport.GutterFunc {
+	return func(info viewport.GutterContext) string {
+		number := info.Index + 1
+		if info.Index < len(lineNumbers) {
+			number = lineNumbers[info.Index] + 1
+		}
+		return lipgloss.NewStyle().Foreground(s.colors.faintColor).Render(
+			fmt.Sprintf(" %*d %s ", 5, number,
+				lipgloss.NewStyle().Foreground(s.colors.fainterColor).Render("│")))
+	}
+}
+
+// rowOfLine returns the row of the viewport showing a line of the logs, or the row of the
+// folded group hiding it
+func (ji *jobItem) rowOfLine(line int) int {
+	row, found := slices.BinarySearch(ji.shownLines, line)
+	if !found {
+		row--
+	}
+	return max(0, row)
+}
+
+// revealLogLine unfolds the groups hiding a line of the selected job's logs and returns its row
+func (m *model) revealLogLine(ji *jobItem, line int) int {
+	changed := false
+	// when showing a single step, the step of the line is shown, which may have been selected already
+	if r, ok := m.stepOnlyLogs(ji); ok {
+		if line < r.start || line >= r.end {
+			m.selectStepOfLine(ji, line)
+		}
+		changed = true
+	}
+	for _, g := range ji.groups {
+		if g.start < line && line <= g.end && ji.isGroupFolded(g) {
+			ji.setGroupFolded(g, false)
+			changed = true
+		}
+	}
+	if changed {
+		m.setJobLogsContent(ji)
+	}
+	return ji.rowOfLine(line)
+}
+
+// revealMatchingLines unfolds the groups with lines the search matches
+func (m *model) revealMatchingLines(ji *jobItem, matches func(string) bool) {
+	changed := false
+	for _, g := range ji.groups {
+		if !ji.isGroupFolded(g) {
+			continue
+		}
+		for i := g.start + 1; i <= g.end && i < len(ji.unstyledLogs); i++ {
+			if matches(ji.unstyledLogs[i]) {
+				ji.setGroupFolded(g, false)
+				changed = true
+				break
+			}
+		}
+	}
+	if changed {
+		m.setJobLogsContent(ji)
+	}
+}
+
+// shownJobLogs returns the selected job when the logs viewport shows its logs
+func (m *model) shownJobLogs() *jobItem {
+	if m.artifactPreview != nil || m.showingSummary() {
+		return nil
+	}
+	ji := m.getSelectedJobItem()
+	if ji == nil || len(ji.renderedLogs) == 0 {
+		return nil
+	}
+	return ji
+}
+
+// foldableJobLogs returns the selected job when its groups can be folded, which
+// they can't be while searching
+func (m *model) foldableJobLogs() *jobItem {
+	if m.logsInput.Value() != "" {
+		return nil
+	}
+	return m.shownJobLogs()
+}
+
+// toggleGroupAtTop folds or unfolds the innermost group at the top of the logs viewport,
+// or the first group shown when the top line isn't part of one.
+func (m *model) toggleGroupAtTop() {
+	ji := m.foldableJobLogs()
+	if ji == nil {
+		return
+	}
+
+	top := m.logsViewport.YOffset()
+	g, ok := ji.innermostGroupAt(ji.shownLines[min(top, len(ji.shownLines)-1)])
+	if !ok {
+		for row := top; row < min(len(ji.shownLines), top+m.logsViewport.Height()); row++ {
+			if g, ok = ji.innermostGroupAt(ji.shownLines[row]); ok && g.start == ji.shownLines[row] {
+				break
+			}
+			ok = false
+		}
+	}
+	if !ok {
+		return
+	}
+
+	ji.setGroupFolded(g, !ji.isGroupFolded(g))
+	m.setJobLogsContent(ji)
+	m.logsViewport.SetYOffset(min(top, ji.rowOfLine(g.start)))
+}
+
+// setAllGroupsFolded folds or unfolds all groups, keeping the line at the top in view
+func (m *model) setAllGroupsFolded(folded bool) {
+	ji := m.foldableJobLogs()
+	if ji == nil {
+		return
+	}
+
+	top := ji.shownLines[min(m.logsViewport.YOffset(), len(ji.shownLines)-1)]
+	for _, g := range ji.groups {
+		ji.setGroupFolded(g, folded)
+	}
+	m.setJobLogsContent(ji)
+	m.logsViewport.SetYOffset(ji.rowOfLine(top))
+}
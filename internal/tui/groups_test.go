@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+func TestFoldingLogGroups(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "test", State: api.StatusCompleted, Conclusion: api.ConclusionFailure,
+				Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	logs := parser.ParseJobLogs(strings.Join([]string{
+		"2025-06-01T10:00:00Z ##[group]Runner Image",
+		"2025-06-01T10:00:00Z Image: ubuntu-24.04",
+		"2025-06-01T10:00:00Z Version: 20250601",
+		"2025-06-01T10:00:00Z ##[endgroup]",
+		"2025-06-01T10:00:01Z ##[group]Run go test ./...",
+		"2025-06-01T10:00:01Z go test ./...",
+		"2025-06-01T10:00:01Z ##[endgroup]",
+		"2025-06-01T10:00:02Z --- FAIL: TestFlaky",
+		"2025-06-01T10:00:02Z ##[group]Post processing",
+		"2025-06-01T10:00:02Z ##[error]Process completed with exit code 1.",
+		"2025-06-01T10:00:02Z ##[endgroup]",
+	}, "\n"))
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: logs})
+	m = updated.(model)
+	m.focusPane(PaneLogs)
+
+	// groups start folded unless they have errors
+	ji := m.getSelectedJobItem()
+	if want := []int{0, 4, 7, 8, 9, 10}; !slices.Equal(ji.shownLines, want) {
+		t.Fatalf("got shown lines %v, want %v", ji.shownLines, want)
+	}
+	if !strings.Contains(ji.shownUnstyledLogs[0], "Runner Image (2 lines)") {
+		t.Errorf("expected folded groups to show their size, got %q", ji.shownUnstyledLogs[0])
+	}
+	// line numbers are the lines' numbers in the full logs
+	if view := ansi.Strip(m.logsViewport.View()); !strings.Contains(view, "8 │ --- FAIL: TestFlaky") {
+		t.Errorf("expected the line numbers to skip folded lines, got %q", view)
+	}
+
+	m.logsViewport.SetYOffset(0)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	m = updated.(model)
+	if want := []int{0, 1, 2, 3, 4, 7, 8, 9, 10}; !slices.Equal(ji.shownLines, want) {
+		t.Errorf("expected the group at the top to unfold, got shown lines %v", ji.shownLines)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: '-', Text: "-"})
+	m = updated.(model)
+	if want := []int{0, 4, 7, 8}; !slices.Equal(ji.shownLines, want) {
+		t.Errorf("expected all groups to fold, got shown lines %v", ji.shownLines)
+	}
+
+	// jumping to the error unfolds its group
+	m.goToErrorInLogs()
+	if got := ji.rowOfLine(ji.errorLine); ji.shownLines[got] != 9 {
+		t.Errorf("expected the error line to be shown, got shown lines %v", ji.shownLines)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: '+', Text: "+"})
+	m = updated.(model)
+	if len(ji.shownLines) != len(logs) {
+		t.Errorf("expected all groups to unfold, got shown lines %v", ji.shownLines)
+	}
+
+	// searching unfolds the groups with matches
+	updated, _ = m.Update(tea.KeyPressMsg{Code: '-', Text: "-"})
+	m = updated.(model)
+	m.logsInput.SetValue("Version")
+	m.logsInput.Focus()
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+	if want := []int{0, 1, 2, 3, 4, 7, 8}; !slices.Equal(ji.shownLines, want) {
+		t.Errorf("expected the group with the match to unfold, got shown lines %v", ji.shownLines)
+	}
+	if m.numHighlights != 1 {
+		t.Errorf("expected the match to be found, got %d matches", m.numHighlights)
+	}
+}
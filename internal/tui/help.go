@@ -24,12 +24,36 @@ func (km keyMap) FullHelp() [][]key.Binding {
 			applySearchKey,
 			nextSearchMatchKey,
 			prevSearchMatchKey,
+			listSearchMatchesKey,
+			toggleRegexKey,
+			toggleCaseSensitiveKey,
+			toggleWholeWordKey,
+			prevSearchKey,
+			nextSearchKey,
+		},
+		{
+			summaryTabKey,
+			toggleGroupKey,
+			unfoldAllGroupsKey,
+			foldAllGroupsKey,
+			gutterModeKey,
+			stepOnlyKey,
 		},
 		{
 			rerunKey,
+			cancelRunKey,
+			forceCancelRunKey,
+			reviewDeploymentsKey,
+			openAnnotationKey,
+			artifactsKey,
+			prevAttemptKey,
+			nextAttemptKey,
+			compareKey,
 			openUrlKey,
 			openPRKey,
 			refreshAllKey,
+			filterRunsKey,
+			dispatchWorkflowKey,
 		},
 		{
 			modeKey,
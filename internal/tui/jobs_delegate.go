@@ -1,9 +1,9 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"strings"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
@@ -23,16 +23,30 @@ type jobItem struct {
 	job                *data.WorkflowJob
 	logs               []data.LogsWithTime
 	logsErr            error
-	logsStderr         string
 	renderedLogs       []string
 	unstyledLogs       []string
+	groups             []logGroup
+	foldedGroups       map[int]bool // the groups the user folded or unfolded, by their first line
+	shownLines         []int        // the lines of the logs that aren't in folded groups
+	shownLogs          []string     // the rendered shown lines
+	shownUnstyledLogs  []string
 	errorLine          int
 	renderedText       string
 	title              string
 	initiatedLogsFetch bool
+	fetchingLogs       bool
 	loadingLogs        bool
+	partialLogs        bool // logs were fetched while the job was still running
 	loadingSteps       bool
 	steps              []*stepItem
+	stepsLines         map[int]stepLogs // the lines of each step, nil until mapped again after the logs or steps changed
+	annotations        []api.Annotation
+	annotationLines    []int // the log line each annotation was printed at, -1 when not found
+	fetchedAnnotations bool
+	summary            string // the markdown the job wrote to GITHUB_STEP_SUMMARY
+	renderedSummary    string
+	fetchedSummary     bool
+	summaryErr         error // why the summary couldn't be fetched
 	spinner            spinner.Model
 	styles             styles
 }
@@ -48,6 +62,9 @@ func (i *jobItem) Title() string {
 
 // Description implements charm.land/bubbles.list.DefaultItem.Description
 func (i *jobItem) Description() string {
+	if i.job.Kind == data.JobKindStatusContext && i.job.Title != "" {
+		return i.job.Title
+	}
 	if i.job.Bucket == data.CheckBucketActionRequired {
 		return "Action required"
 	}
@@ -124,8 +141,29 @@ func (d *jobsDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 
 func (ji *jobItem) isStatusInProgress() bool {
 	return ji.job.State == api.StatusInProgress || ji.job.State == api.StatusPending ||
-		ji.job.State == api.StatusQueued || (ji.logsErr != nil &&
-		strings.Contains(ji.logsStderr, "is still in progress;"))
+		ji.job.State == api.StatusQueued
+}
+
+// isLive reports whether the job is a running GitHub Actions job. GitHub usually serves
+// its logs only once it finished, until then its steps show its progress.
+func (ji *jobItem) isLive() bool {
+	return ji.job.State == api.StatusInProgress && ji.job.Title == "" &&
+		ji.job.Kind == data.JobKindGithubActions
+}
+
+// shouldFetchLogs reports whether the job's logs should be fetched once it's selected.
+// A running job's logs are fetched once, and again once it completed.
+func (ji *jobItem) shouldFetchLogs() bool {
+	if ji.fetchingLogs {
+		return false
+	}
+
+	if !ji.initiatedLogsFetch {
+		return ji.isLive() || !ji.isStatusInProgress()
+	}
+
+	return !ji.isStatusInProgress() &&
+		(ji.partialLogs || errors.Is(ji.logsErr, api.ErrLogsInProgress))
 }
 
 func (ji *jobItem) hasInProgressSteps() bool {
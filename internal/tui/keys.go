@@ -63,6 +63,56 @@ var (
 		key.WithHelp("←", "move left"),
 	)
 
+	openAnnotationKey = key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open annotation in $EDITOR"),
+	)
+
+	toggleGroupKey = key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "fold/unfold group at top"),
+	)
+
+	unfoldAllGroupsKey = key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "unfold all groups"),
+	)
+
+	foldAllGroupsKey = key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "fold all groups"),
+	)
+
+	gutterModeKey = key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "cycle timestamps gutter"),
+	)
+
+	stepOnlyKey = key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "show selected step only"),
+	)
+
+	summaryTabKey = key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "logs/summary"),
+	)
+
+	artifactsKey = key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "artifacts"),
+	)
+
+	downloadArtifactKey = key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "download"),
+	)
+
+	previewArtifactKey = key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "preview"),
+	)
+
 	searchKey = key.NewBinding(
 		key.WithKeys("/"),
 		key.WithHelp("/", "search in pane"),
@@ -83,6 +133,36 @@ var (
 		key.WithHelp("enter", "apply search"),
 	)
 
+	toggleRegexKey = key.NewBinding(
+		key.WithKeys("alt+r"),
+		key.WithHelp("alt+r", "toggle regex search"),
+	)
+
+	toggleCaseSensitiveKey = key.NewBinding(
+		key.WithKeys("alt+c"),
+		key.WithHelp("alt+c", "toggle case sensitive search"),
+	)
+
+	toggleWholeWordKey = key.NewBinding(
+		key.WithKeys("alt+w"),
+		key.WithHelp("alt+w", "toggle whole word search"),
+	)
+
+	prevSearchKey = key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "previous search"),
+	)
+
+	nextSearchKey = key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "next search"),
+	)
+
+	listSearchMatchesKey = key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "list matching lines"),
+	)
+
 	nextSearchMatchKey = key.NewBinding(
 		key.WithKeys("n", "ctrl+n"),
 		key.WithHelp("ctrl+n", "next match"),
@@ -103,6 +183,91 @@ var (
 		key.WithHelp("ctrl+r", "rerun"),
 	)
 
+	cancelRunKey = key.NewBinding(
+		key.WithKeys("ctrl+x"),
+		key.WithHelp("ctrl+x", "cancel run"),
+	)
+
+	forceCancelRunKey = key.NewBinding(
+		key.WithKeys("ctrl+alt+x"),
+		key.WithHelp("ctrl+alt+x", "force cancel run"),
+	)
+
+	reviewDeploymentsKey = key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "review deployments"),
+	)
+
+	toggleKey = key.NewBinding(
+		key.WithKeys("space"),
+		key.WithHelp("space", "toggle"),
+	)
+
+	approveDeploymentsKey = key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "approve"),
+	)
+
+	rejectDeploymentsKey = key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reject"),
+	)
+
+	compareKey = key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "compare logs with another job"),
+	)
+
+	nextHunkKey = key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next hunk"),
+	)
+
+	prevHunkKey = key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous hunk"),
+	)
+
+	prevAttemptKey = key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous run attempt"),
+	)
+
+	nextAttemptKey = key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next run attempt"),
+	)
+
+	dispatchWorkflowKey = key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "run workflow"),
+	)
+
+	filterRunsKey = key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "filter repo runs"),
+	)
+
+	nextFormFieldKey = key.NewBinding(
+		key.WithKeys("tab", "down"),
+		key.WithHelp("tab", "next field"),
+	)
+
+	prevFormFieldKey = key.NewBinding(
+		key.WithKeys("shift+tab", "up"),
+		key.WithHelp("shift+tab", "previous field"),
+	)
+
+	submitFormKey = key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "submit"),
+	)
+
+	closeFormKey = key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "close"),
+	)
+
 	helpKey = key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "toggle help"),
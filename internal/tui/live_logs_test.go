@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestLogsOfRunningJob(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	job := data.WorkflowJob{
+		Id:    "10",
+		Name:  "integration",
+		State: api.StatusInProgress,
+		Kind:  data.JobKindGithubActions,
+	}
+	updated, _ := m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{job},
+	}}})
+	m = updated.(model)
+
+	ji := m.getSelectedJobItem()
+	if ji == nil {
+		t.Fatal("expected the job to be selected")
+	}
+	if !ji.shouldFetchLogs() {
+		t.Fatal("expected the logs of a running job to be fetched")
+	}
+	m.makeFetchJobLogsCmd()
+
+	// GitHub usually doesn't serve the logs of running jobs, their steps are shown instead
+	steps := api.WorkflowRunStepsQuery{}
+	check := api.CheckRunWithSteps{DatabaseId: 10}
+	check.Steps.Nodes = []api.Step{
+		{Number: 1, Name: "Set up job", Status: api.StatusCompleted, Conclusion: api.ConclusionSuccess},
+		{Number: 2, Name: "Run integration tests", Status: api.StatusInProgress},
+	}
+	steps.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes = []api.CheckRunWithSteps{check}
+	updated, _ = m.Update(workflowRunStepsFetchedMsg{runId: "1", data: steps})
+	m = updated.(model)
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "10", partial: true,
+		err: fmt.Errorf("%w: 404 Not Found", api.ErrLogsInProgress)})
+	m = updated.(model)
+	if view := ansi.Strip(m.viewLogs()); !strings.Contains(view, "only serves the logs once") ||
+		!strings.Contains(view, "Run integration tests") {
+		t.Errorf("expected the steps to be shown until the logs are served, got %q", view)
+	}
+
+	updated, _ = m.Update(runJobsFetchedMsg{runId: "1", jobs: []data.WorkflowJob{job}})
+	m = updated.(model)
+	if m.getSelectedJobItem().fetchingLogs {
+		t.Error("expected the logs not to be fetched again while the job runs")
+	}
+
+	job.State = api.StatusCompleted
+	job.Conclusion = api.ConclusionSuccess
+	updated, _ = m.Update(runJobsFetchedMsg{runId: "1", jobs: []data.WorkflowJob{job}})
+	m = updated.(model)
+	if !m.getSelectedJobItem().fetchingLogs {
+		t.Fatal("expected the logs to be fetched once the job completed")
+	}
+
+	lines := []data.LogsWithTime{{Log: "one"}, {Log: "two"}, {Log: "three"}}
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "10", logs: lines})
+	m = updated.(model)
+	ji = m.getSelectedJobItem()
+	if got := strings.Join(ji.unstyledLogs, ","); got != "one,two,three" {
+		t.Errorf("expected the complete logs to be shown, got %s", got)
+	}
+	if ji.shouldFetchLogs() {
+		t.Error("expected the complete logs not to be fetched again")
+	}
+}
+
+func TestScrollingPausesFollowingLogs(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{{
+			Id:    "10",
+			Name:  "integration",
+			State: api.StatusInProgress,
+			Kind:  data.JobKindGithubActions,
+		}},
+	}}})
+	m = updated.(model)
+
+	lines := make([]data.LogsWithTime, 0)
+	for i := range 200 {
+		lines = append(lines, data.LogsWithTime{Log: fmt.Sprintf("line %d", i)})
+	}
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "10", partial: true, logs: lines})
+	m = updated.(model)
+	m.focusPane(PaneLogs)
+	if !m.followLogs || !m.logsViewport.AtBottom() {
+		t.Fatal("expected the logs of the running job to be followed")
+	}
+
+	updated, _ = m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	m = updated.(model)
+	if m.followLogs {
+		t.Error("expected scrolling up with the mouse wheel to pause following the logs")
+	}
+
+	updated, _ = m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	m = updated.(model)
+	if !m.followLogs {
+		t.Error("expected scrolling back to the bottom to resume following the logs")
+	}
+}
+
+func TestMissingLogsOfCompletedJob(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{{
+			Id:    "10",
+			Name:  "integration",
+			State: api.StatusCompleted,
+			Kind:  data.JobKindGithubActions,
+		}},
+	}}})
+	m = updated.(model)
+
+	steps := api.WorkflowRunStepsQuery{}
+	check := api.CheckRunWithSteps{DatabaseId: 10}
+	check.Steps.Nodes = []api.Step{
+		{Number: 1, Name: "Set up job", Status: api.StatusCompleted, Conclusion: api.ConclusionSuccess},
+	}
+	steps.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes = []api.CheckRunWithSteps{check}
+	updated, _ = m.Update(workflowRunStepsFetchedMsg{runId: "1", data: steps})
+	m = updated.(model)
+
+	m.makeFetchJobLogsCmd()
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "10",
+		err: fmt.Errorf("%w: 404 Not Found", api.ErrLogsNotFound)})
+	m = updated.(model)
+
+	ji := m.getSelectedJobItem()
+	if ji.isStatusInProgress() || ji.shouldFetchLogs() {
+		t.Error("expected the missing logs of a completed job not to be fetched again")
+	}
+	if view := ansi.Strip(m.viewLogs()); !strings.Contains(view, "No logs were found") {
+		t.Errorf("expected the logs to be shown as missing, got %q", view)
+	}
+}
@@ -0,0 +1,393 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxDiffEdits bounds the work of diffing two logs. The parts of logs that differ
+// by more lines are shown as a single change between their common start and end.
+const maxDiffEdits = 2000
+
+// diffContextLines is the number of unchanged lines shown above a hunk jumped to
+const diffContextLines = 3
+
+type diffOpKind int
+
+const (
+	diffEqual diffOpKind = iota
+	diffDelete
+	diffInsert
+)
+
+// diffOp is a line of the diff, a is its index in the left logs and b in the right ones
+type diffOp struct {
+	kind diffOpKind
+	a, b int
+}
+
+// diffLines returns the edits turning the lines of a into the lines of b
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, max(len(a), len(b)))
+	for i := range prefix {
+		ops = append(ops, diffOp{kind: diffEqual, a: i, b: i})
+	}
+
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	middle, ok := myersDiff(middleA, middleB, maxDiffEdits)
+	if !ok {
+		middle = make([]diffOp, 0, len(middleA)+len(middleB))
+		for i := range middleA {
+			middle = append(middle, diffOp{kind: diffDelete, a: i, b: -1})
+		}
+		for i := range middleB {
+			middle = append(middle, diffOp{kind: diffInsert, a: -1, b: i})
+		}
+	}
+	for _, op := range middle {
+		if op.a >= 0 {
+			op.a += prefix
+		}
+		if op.b >= 0 {
+			op.b += prefix
+		}
+		ops = append(ops, op)
+	}
+
+	for i := range suffix {
+		ops = append(ops, diffOp{kind: diffEqual, a: len(a) - suffix + i, b: len(b) - suffix + i})
+	}
+	return ops
+}
+
+// myersDiff finds the shortest edit script between a and b with Myers' algorithm.
+// It gives up when more than maxEdits lines were deleted or inserted.
+func myersDiff(a, b []string, maxEdits int) ([]diffOp, bool) {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+
+	// trace[d] holds the furthest x reached on the diagonals -d..d after d edits
+	trace := make([][]int, 0)
+	for d := 0; d <= limit; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				return backtrackDiff(trace, n, m), true
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+	return nil, false
+}
+
+func backtrackDiff(trace [][]int, n, m int) []diffOp {
+	ops := make([]diffOp, 0, max(n, m))
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: diffEqual, a: x, b: y})
+		}
+		if prevK == k+1 {
+			ops = append(ops, diffOp{kind: diffInsert, a: -1, b: prevY})
+		} else {
+			ops = append(ops, diffOp{kind: diffDelete, a: prevX, b: -1})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		ops = append(ops, diffOp{kind: diffEqual, a: x, b: y})
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// diffRow is a row of the side by side diff, left and right are the indices of
+// its lines or -1 when the side has no line at the row
+type diffRow struct {
+	left, right int
+	changed     bool
+}
+
+// alignDiff lays the diff out side by side, pairing the deleted and inserted lines
+// of each change. It returns the rows and the rows at which each change starts.
+func alignDiff(ops []diffOp) ([]diffRow, []int) {
+	rows := make([]diffRow, 0, len(ops))
+	hunks := make([]int, 0)
+	deleted, inserted := make([]int, 0), make([]int, 0)
+
+	flush := func() {
+		if len(deleted) == 0 && len(inserted) == 0 {
+			return
+		}
+		hunks = append(hunks, len(rows))
+		for i := range max(len(deleted), len(inserted)) {
+			row := diffRow{left: -1, right: -1, changed: true}
+			if i < len(deleted) {
+				row.left = deleted[i]
+			}
+			if i < len(inserted) {
+				row.right = inserted[i]
+			}
+			rows = append(rows, row)
+		}
+		deleted, inserted = deleted[:0], inserted[:0]
+	}
+
+	for _, op := range ops {
+		switch op.kind {
+		case diffDelete:
+			deleted = append(deleted, op.a)
+		case diffInsert:
+			inserted = append(inserted, op.b)
+		default:
+			flush()
+			rows = append(rows, diffRow{left: op.a, right: op.b})
+		}
+	}
+	flush()
+	return rows, hunks
+}
+
+// compareSide is one of the jobs whose logs are compared
+type compareSide struct {
+	jobId string
+	title string
+	lines []string
+	// normalized are the lines without timestamps, runner paths and GUIDs
+	normalized []string
+}
+
+func newCompareSide(ji *jobItem) compareSide {
+	title := ji.job.Name
+	if ji.job.RunAttempt > 0 {
+		title = fmt.Sprintf("%s (attempt %d)", title, ji.job.RunAttempt)
+	}
+
+	side := compareSide{
+		jobId:      ji.job.Id,
+		title:      title,
+		lines:      make([]string, 0, len(ji.logs)),
+		normalized: make([]string, 0, len(ji.logs)),
+	}
+	for _, l := range ji.logs {
+		side.lines = append(side.lines, strings.ReplaceAll(ansi.Strip(l.Log), "\t", "    "))
+		side.normalized = append(side.normalized, l.Normalized())
+	}
+	return side
+}
+
+// logsDiff shows the logs of two jobs side by side, aligned by their differences
+type logsDiff struct {
+	left, right compareSide
+	rows        []diffRow
+	hunks       []int
+	hunk        int // the hunk last jumped to, -1 before jumping to any
+	deleted     int
+	inserted    int
+	viewport    viewport.Model
+	width       int
+	styles      styles
+}
+
+func newLogsDiff(s styles, left, right compareSide, width, height int) *logsDiff {
+	rows, hunks := alignDiff(diffLines(left.normalized, right.normalized))
+	d := &logsDiff{
+		left:     left,
+		right:    right,
+		rows:     rows,
+		hunks:    hunks,
+		hunk:     -1,
+		viewport: viewport.New(),
+		styles:   s,
+	}
+	for _, row := range rows {
+		if row.changed && row.left >= 0 {
+			d.deleted++
+		}
+		if row.changed && row.right >= 0 {
+			d.inserted++
+		}
+	}
+	d.viewport.KeyMap.Right = rightKey
+	d.viewport.KeyMap.Left = leftKey
+	d.setSize(width, height)
+	d.nextHunk()
+	return d
+}
+
+func (d *logsDiff) headerHeight() int {
+	return 2
+}
+
+func (d *logsDiff) setSize(width, height int) {
+	d.width = width
+	d.viewport.SetWidth(width)
+	d.viewport.SetHeight(max(0, height-d.headerHeight()))
+	d.viewport.SetContentLines(d.renderRows())
+}
+
+func (d *logsDiff) columnWidth() int {
+	return max(0, (d.width-3)/2)
+}
+
+func (d *logsDiff) renderRows() []string {
+	numWidth := len(strconv.Itoa(max(len(d.left.lines), len(d.right.lines))))
+	cw := d.columnWidth()
+	sep := d.styles.faintFgStyle.Render(" │ ")
+	deletedStyle := lipgloss.NewStyle().Foreground(d.styles.colors.errorColor)
+	insertedStyle := lipgloss.NewStyle().Foreground(d.styles.colors.successColor)
+
+	renderSide := func(lines []string, idx int, changed bool, style lipgloss.Style) string {
+		if idx < 0 {
+			return strings.Repeat(" ", cw)
+		}
+		num := d.styles.faintFgStyle.Render(fmt.Sprintf("%*d ", numWidth, idx+1))
+		text := ansi.Truncate(lines[idx], max(0, cw-numWidth-1), Ellipsis)
+		if changed {
+			text = style.Render(text)
+		}
+		return lipgloss.NewStyle().Width(cw).MaxWidth(cw).Render(num + text)
+	}
+
+	lines := make([]string, 0, len(d.rows))
+	for _, row := range d.rows {
+		lines = append(lines, renderSide(d.left.lines, row.left, row.changed, deletedStyle)+sep+
+			renderSide(d.right.lines, row.right, row.changed, insertedStyle))
+	}
+	return lines
+}
+
+func (d *logsDiff) nextHunk() {
+	if len(d.hunks) == 0 {
+		return
+	}
+	d.hunk = min(d.hunk+1, len(d.hunks)-1)
+	d.viewport.SetYOffset(d.hunks[d.hunk] - diffContextLines)
+}
+
+func (d *logsDiff) prevHunk() {
+	if len(d.hunks) == 0 {
+		return
+	}
+	d.hunk = max(d.hunk-1, 0)
+	d.viewport.SetYOffset(d.hunks[d.hunk] - diffContextLines)
+}
+
+func (d *logsDiff) Update(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, nextHunkKey):
+		d.nextHunk()
+	case key.Matches(msg, prevHunkKey):
+		d.prevHunk()
+	case key.Matches(msg, gotoTopKey):
+		d.viewport.GotoTop()
+	case key.Matches(msg, gotoBottomKey):
+		d.viewport.GotoBottom()
+	default:
+		var cmd tea.Cmd
+		d.viewport, cmd = d.viewport.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+func (d *logsDiff) View() string {
+	title := makePill("Diff", d.styles.focusedPaneTitleStyle, d.styles.colors.focusedColor)
+	summary := "no differences"
+	if len(d.hunks) > 0 {
+		summary = fmt.Sprintf("hunk %d/%d · -%d +%d", d.hunk+1, len(d.hunks), d.deleted, d.inserted)
+	}
+	title = lipgloss.JoinHorizontal(lipgloss.Top,
+		d.styles.focusedPaneTitleBarStyle.MarginBottom(0).Render(title), " ",
+		d.styles.faintFgStyle.Render(summary+" · n/N next/previous hunk, esc to close"))
+
+	cw := d.columnWidth()
+	heading := lipgloss.NewStyle().Bold(true).Width(cw).MaxWidth(cw)
+	columns := heading.Render(ansi.Truncate(d.left.title, cw, Ellipsis)) +
+		d.styles.faintFgStyle.Render(" │ ") +
+		heading.Render(ansi.Truncate(d.right.title, cw, Ellipsis))
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		ansi.Truncate(title, d.width, Ellipsis), columns, d.viewport.View())
+}
+
+// compareSelectedJob marks the selected job to compare its logs, or compares it with
+// the job marked before
+func (m *model) compareSelectedJob() {
+	ji := m.getSelectedJobItem()
+	if ji == nil || len(ji.logs) == 0 {
+		return
+	}
+
+	side := newCompareSide(ji)
+	if m.compareBase == nil {
+		m.compareBase = &side
+		return
+	}
+	if m.compareBase.jobId == side.jobId {
+		m.compareBase = nil
+		return
+	}
+
+	m.logsDiff = newLogsDiff(m.styles, *m.compareBase, side, m.width, m.getMainContentHeight())
+	m.compareBase = nil
+}
+
+// viewCompareHint tells which job the next one will be compared with
+func (m *model) viewCompareHint() string {
+	if m.compareBase == nil {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(m.styles.colors.warnColor).Render(
+		fmt.Sprintf("comparing with %s, c on another job", m.compareBase.title))
+}
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestDiffLinesAlignsChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      []string
+		wantRows  []diffRow
+		wantHunks []int
+	}{
+		{
+			name:      "equal",
+			a:         []string{"a", "b"},
+			b:         []string{"a", "b"},
+			wantRows:  []diffRow{{left: 0, right: 0}, {left: 1, right: 1}},
+			wantHunks: []int{},
+		},
+		{
+			name: "changed line",
+			a:    []string{"setup", "FAIL TestFlaky", "done"},
+			b:    []string{"setup", "ok TestFlaky", "done"},
+			wantRows: []diffRow{
+				{left: 0, right: 0},
+				{left: 1, right: 1, changed: true},
+				{left: 2, right: 2},
+			},
+			wantHunks: []int{1},
+		},
+		{
+			name: "inserted and deleted lines",
+			a:    []string{"a", "retrying", "b", "c"},
+			b:    []string{"a", "b", "c", "d"},
+			wantRows: []diffRow{
+				{left: 0, right: 0},
+				{left: 1, right: -1, changed: true},
+				{left: 2, right: 1},
+				{left: 3, right: 2},
+				{left: -1, right: 3, changed: true},
+			},
+			wantHunks: []int{1, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, hunks := alignDiff(diffLines(tt.a, tt.b))
+			if !slices.Equal(rows, tt.wantRows) {
+				t.Errorf("got rows %+v, want %+v", rows, tt.wantRows)
+			}
+			if !slices.Equal(hunks, tt.wantHunks) {
+				t.Errorf("got hunks %v, want %v", hunks, tt.wantHunks)
+			}
+		})
+	}
+}
+
+func TestComparingJobLogs(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "shard 1", State: api.StatusCompleted, Conclusion: api.ConclusionFailure,
+				Kind: data.JobKindGithubActions},
+			{Id: "12", Name: "shard 2", State: api.StatusCompleted, Conclusion: api.ConclusionSuccess,
+				Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: []data.LogsWithTime{
+		{Log: "Runner 8f14e45f-ceea-467a-9575-32b6e1a6d2a9 started at 2025-06-01T10:00:01Z"},
+		{Log: "cd /home/runner/work/neovim/neovim"},
+		{Log: "FAIL TestFlaky (1.20s)"},
+		{Log: "exit 1"},
+	}})
+	m = updated.(model)
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "12", logs: []data.LogsWithTime{
+		{Log: "Runner 1679091c-5a88-4e9f-a2d4-0b6a7ecc6d8c started at 2025-06-02T11:30:45Z"},
+		{Log: `cd D:\a\neovim\neovim`},
+		{Log: "ok TestFlaky (1.20s)"},
+	}})
+	m = updated.(model)
+
+	m.focusPane(PaneJobs)
+	if ji := m.getSelectedJobItem(); ji == nil || ji.job.Id != "11" {
+		t.Fatalf("expected the failing shard to be selected, got %+v", ji)
+	}
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	m = updated.(model)
+	if m.compareBase == nil || !strings.Contains(ansi.Strip(m.viewLogs()), "comparing with shard 1") {
+		t.Fatal("expected the failing shard to be marked for comparison")
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m = updated.(model)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	m = updated.(model)
+	if m.logsDiff == nil {
+		t.Fatal("expected the logs of the two shards to be compared")
+	}
+
+	// the runner GUIDs, timestamps and workspace paths are ignored
+	d := m.logsDiff
+	want := []diffRow{
+		{left: 0, right: 0},
+		{left: 1, right: 1},
+		{left: 2, right: 2, changed: true},
+		{left: 3, right: -1, changed: true},
+	}
+	if !slices.Equal(d.rows, want) {
+		t.Errorf("got rows %+v, want %+v", d.rows, want)
+	}
+	view := ansi.Strip(m.View().Content)
+	for _, s := range []string{"shard 1", "shard 2", "hunk 1/1 · -2 +1", "FAIL TestFlaky", "ok TestFlaky"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected the diff view to contain %q, got %q", s, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(model)
+	if m.logsDiff != nil {
+		t.Error("expected esc to close the diff")
+	}
+}
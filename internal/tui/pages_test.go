@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	graphql "github.com/hasura/go-graphql-client"
+)
+
+func TestFetchingAllPRChecksPages(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", PRNumber: "34671"})
+
+	cursor := ""
+	for _, file := range []string{
+		"./testdata/fetchPRManyContextsPage1.json",
+		"./testdata/fetchPRManyContextsPage2.json",
+	} {
+		d, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed reading mock data file %v", err)
+		}
+
+		res := struct{ Data api.PRCheckRunsQuery }{}
+		if err := graphql.UnmarshalGraphQL(d, &res); err != nil {
+			t.Fatal(err)
+		}
+
+		pr := res.Data.Resource.PullRequest
+		contexts := pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts
+		updated, _ := m.Update(workflowRunsFetchedMsg{
+			cursor: cursor,
+			pr:     pr,
+			runs:   makeWorkflowRuns(contexts.Nodes),
+		})
+		m = updated.(model)
+		cursor = contexts.PageInfo.EndCursor
+	}
+
+	if len(m.workflowRuns) != 1 {
+		t.Fatalf("expected workflow runs to have length of 1, got: %d", len(m.workflowRuns))
+	}
+	if len(m.workflowRuns[0].Jobs) != 130 {
+		t.Fatalf("expected all 130 jobs to be kept, got: %d", len(m.workflowRuns[0].Jobs))
+	}
+}
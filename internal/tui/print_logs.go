@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+type LogsOpts struct {
+	Out        io.Writer
+	JobID      string // set when a job URL was passed, only its logs are printed
+	Job        string // a job ID or a part of its name
+	Step       string // a step number or a part of its name
+	Failed     bool   // only print the failed steps of failed jobs
+	Raw        bool   // print the logs as returned by the API
+	Timestamps bool
+}
+
+// PrintLogs prints the parsed logs of the jobs of a PR or a run without starting the tui.
+func PrintLogs(opts ModelOpts, lo LogsOpts) error {
+	m := NewModel(opts)
+	runs, err := m.fetchRunsWithJobs()
+	if err != nil {
+		return err
+	}
+
+	type selectedJob struct {
+		run data.WorkflowRun
+		job data.WorkflowJob
+	}
+	selected := make([]selectedJob, 0)
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			if job.Kind == data.JobKindGithubActions && lo.matchesJob(job) {
+				selected = append(selected, selectedJob{run: run, job: job})
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return errors.New("no jobs with logs matched")
+	}
+
+	printed := 0
+	for _, s := range selected {
+		raw, err := m.client.FetchJobLogs(m.repo, s.job.Id)
+		if err != nil {
+			return fmt.Errorf("failed fetching the logs of %s: %w", checkName(s.run, s.job), err)
+		}
+
+		steps := lo.selectSteps(s.job)
+		if steps != nil && len(steps) == 0 {
+			continue
+		}
+
+		if len(selected) > 1 {
+			if printed > 0 {
+				lipgloss.Fprintln(lo.Out)
+			}
+			lipgloss.Fprintln(lo.Out, m.styles.stepStartMarkerStyle.Bold(true).Render(
+				"==> "+checkName(s.run, s.job)))
+		}
+		m.printJobLogs(lo, raw, s.job.Steps, steps)
+		printed++
+	}
+
+	if printed == 0 {
+		return errors.New("no steps matched")
+	}
+	return nil
+}
+
+func (lo LogsOpts) matchesJob(job data.WorkflowJob) bool {
+	if lo.JobID != "" && job.Id != lo.JobID {
+		return false
+	}
+	if lo.Failed && job.Bucket != data.CheckBucketFail {
+		return false
+	}
+	return lo.Job == "" || job.Id == lo.Job ||
+		strings.Contains(strings.ToLower(job.Name), strings.ToLower(lo.Job))
+}
+
+// selectSteps returns the numbers of the steps to print, or nil to print all of them.
+func (lo LogsOpts) selectSteps(job data.WorkflowJob) []int {
+	if lo.Step == "" && !lo.Failed {
+		return nil
+	}
+
+	// without steps, e.g. when a job was skipped, there is nothing to narrow down
+	if lo.Step == "" && len(job.Steps) == 0 {
+		return nil
+	}
+
+	number, err := strconv.Atoi(lo.Step)
+	steps := make([]int, 0)
+	for _, step := range job.Steps {
+		if lo.Failed && data.GetConclusionBucket(step.Conclusion) != data.CheckBucketFail {
+			continue
+		}
+		if lo.Step != "" && !(err == nil && step.Number == number) &&
+			!strings.Contains(strings.ToLower(step.Name), strings.ToLower(lo.Step)) {
+			continue
+		}
+		steps = append(steps, step.Number)
+	}
+	return steps
+}
+
+func (m *model) printJobLogs(lo LogsOpts, raw string, steps []api.Step, selectedSteps []int) {
+	// the parsed logs have a line for every raw line, so both can be filtered by index
+	rawLines := slices.Collect(strings.Lines(strings.TrimPrefix(raw, "\ufeff")))
+	logs := parser.ParseJobLogs(raw)
+	stepsLogs := mapStepsToLogs(logs, steps)
+
+	for i, log := range logs {
+		if selectedSteps != nil && !slices.ContainsFunc(selectedSteps, func(number int) bool {
+			r, ok := stepsLogs[number]
+			return ok && i >= r.start && i < r.end
+		}) {
+			continue
+		}
+
+		if lo.Raw {
+			lipgloss.Fprintln(lo.Out, strings.TrimRight(rawLines[i], "\n"))
+			continue
+		}
+
+		line, _ := renderLogLine(log, 0, m.styles)
+		if lo.Timestamps && !log.Time.IsZero() {
+			line = m.styles.faintFgStyle.Render(log.Time.Local().Format(time.DateTime)) + " " + line
+		}
+		lipgloss.Fprintln(lo.Out, line)
+	}
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestPrintingLogsOfFailedSteps(t *testing.T) {
+	started := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	job := data.WorkflowJob{
+		Id:     "10",
+		Name:   "test",
+		Bucket: data.CheckBucketFail,
+		Kind:   data.JobKindGithubActions,
+		Steps: []api.Step{
+			{Number: 1, Name: "Set up job", Conclusion: api.ConclusionSuccess, StartedAt: started},
+			{
+				Number:     2,
+				Name:       "Run tests",
+				Conclusion: api.ConclusionFailure,
+				StartedAt:  started.Add(2 * time.Second),
+			},
+		},
+	}
+	raw := "\ufeff2025-06-01T10:00:00.1000000Z Current runner version: '2.325.0'\n" +
+		"2025-06-01T10:00:01.9000000Z Complete job name: test\n" +
+		"2025-06-01T10:00:02.2000000Z ##[group]Run go test ./...\n" +
+		"2025-06-01T10:00:02.3000000Z go test ./...\n" +
+		"2025-06-01T10:00:02.4000000Z ##[endgroup]\n" +
+		"2025-06-01T10:00:05.0000000Z ##[error]Process completed with exit code 1.\n"
+
+	lo := LogsOpts{Failed: true}
+	if !lo.matchesJob(job) {
+		t.Fatal("expected the failed job to match")
+	}
+	if lo.matchesJob(data.WorkflowJob{Id: "11", Bucket: data.CheckBucketPass}) {
+		t.Error("expected a passing job not to match --failed")
+	}
+
+	steps := lo.selectSteps(job)
+	if len(steps) != 1 || steps[0] != 2 {
+		t.Fatalf("expected only step 2 to be selected, got %v", steps)
+	}
+
+	m := NewModel(ModelOpts{Repo: "dlvhdr/gh-enhance", RunID: "1"})
+	out := strings.Builder{}
+	lo.Out = &out
+	m.printJobLogs(lo, raw, job.Steps, steps)
+	got := ansi.Strip(out.String())
+	if strings.Contains(got, "runner version") || strings.Contains(got, "Complete job") {
+		t.Errorf("expected the logs of step 1 to be left out, got:\n%s", got)
+	}
+	if !strings.Contains(got, "│  go test ./...") ||
+		!strings.Contains(got, "Error: Process completed with exit code 1.") {
+		t.Errorf("expected the parsed logs of step 2, got:\n%s", got)
+	}
+
+	out.Reset()
+	lo.Raw = true
+	m.printJobLogs(lo, raw, job.Steps, []int{1})
+	want := "2025-06-01T10:00:00.1000000Z Current runner version: '2.325.0'\n" +
+		"2025-06-01T10:00:01.9000000Z Complete job name: test\n"
+	if out.String() != want {
+		t.Errorf("expected the raw logs of step 1, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestMergingOfRepoModeRunPages(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "dlvhdr/gh-dash"})
+	m.workflowRuns = []data.WorkflowRun{{Id: "3"}, {Id: "2"}}
+
+	m.mergeRepoModeRuns(repoModeRunsFetchedMsg{
+		Page: 2,
+		Runs: []data.WorkflowRun{{Id: "2", Name: "updated"}, {Id: "1"}},
+	})
+	if got := runIds(m.workflowRuns); got != "3,2,1" {
+		t.Fatalf("expected next page to be appended, got %s", got)
+	}
+	if m.workflowRuns[1].Name != "updated" {
+		t.Errorf("expected run 2 to be updated, got %q", m.workflowRuns[1].Name)
+	}
+
+	m.mergeRepoModeRuns(repoModeRunsFetchedMsg{
+		Page: 1,
+		Runs: []data.WorkflowRun{{Id: "4"}, {Id: "3"}},
+	})
+	if got := runIds(m.workflowRuns); got != "4,3,2,1" {
+		t.Fatalf("expected refreshed first page to keep older runs, got %s", got)
+	}
+}
+
+func runIds(runs []data.WorkflowRun) string {
+	ids := make([]string, 0, len(runs))
+	for _, run := range runs {
+		ids = append(ids, run.Id)
+	}
+	return strings.Join(ids, ",")
+}
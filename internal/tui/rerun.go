@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+type rerunAction int
+
+const (
+	rerunActionJob rerunAction = iota
+	rerunActionFailedJobs
+	rerunActionAllJobs
+)
+
+type rerunOption struct {
+	label  string
+	action rerunAction
+	debug  bool
+}
+
+// rerunChooser lists the ways the selected run or job can be rerun.
+// The chosen option is applied by the caller.
+type rerunChooser struct {
+	run      *data.WorkflowRun
+	job      *data.WorkflowJob // nil when the run itself is selected
+	options  []rerunOption
+	selected int
+	styles   styles
+}
+
+// newRerunChooser returns nil when there is nothing GitHub can rerun, e.g. for
+// checks reported by external apps.
+func newRerunChooser(s styles, run *data.WorkflowRun, job *data.WorkflowJob) *rerunChooser {
+	c := &rerunChooser{run: run, job: job, styles: s}
+	if job != nil && job.Kind == data.JobKindGithubActions {
+		c.options = append(c.options,
+			rerunOption{label: "Rerun job", action: rerunActionJob},
+			rerunOption{label: "Rerun job with debug logging", action: rerunActionJob, debug: true},
+		)
+	}
+
+	if !hasActionsJobs(run) {
+		return c.orNil()
+	}
+	if hasFailedJobs(run) {
+		c.options = append(c.options,
+			rerunOption{label: "Rerun failed jobs", action: rerunActionFailedJobs},
+			rerunOption{
+				label:  "Rerun failed jobs with debug logging",
+				action: rerunActionFailedJobs,
+				debug:  true,
+			},
+		)
+	}
+	c.options = append(c.options,
+		rerunOption{label: "Rerun all jobs", action: rerunActionAllJobs},
+		rerunOption{label: "Rerun all jobs with debug logging", action: rerunActionAllJobs, debug: true},
+	)
+	return c.orNil()
+}
+
+func (c *rerunChooser) orNil() *rerunChooser {
+	if len(c.options) == 0 {
+		return nil
+	}
+	return c
+}
+
+// hasActionsJobs reports whether the run is a workflow run. The jobs of a
+// PR's runs may not be fetched yet, in which case it's assumed to be one.
+func hasActionsJobs(run *data.WorkflowRun) bool {
+	if len(run.Jobs) == 0 {
+		return true
+	}
+	for _, job := range run.Jobs {
+		if job.Kind == data.JobKindGithubActions {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFailedJobs(run *data.WorkflowRun) bool {
+	if run.Bucket == data.CheckBucketFail || run.Bucket == data.CheckBucketCancel {
+		return true
+	}
+	for _, job := range run.Jobs {
+		if job.Bucket == data.CheckBucketFail || job.Bucket == data.CheckBucketCancel {
+			return true
+		}
+	}
+	return false
+}
+
+// Update moves the selection, returning the chosen option once enter or
+// the number of an option is pressed.
+func (c *rerunChooser) Update(msg tea.KeyPressMsg) *rerunOption {
+	switch {
+	case key.Matches(msg, nextRowKey, nextFormFieldKey):
+		c.selected = (c.selected + 1) % len(c.options)
+	case key.Matches(msg, prevRowKey, prevFormFieldKey):
+		c.selected = (c.selected - 1 + len(c.options)) % len(c.options)
+	case key.Matches(msg, submitFormKey):
+		return &c.options[c.selected]
+	default:
+		if i, err := strconv.Atoi(msg.String()); err == nil && i >= 1 && i <= len(c.options) {
+			return &c.options[i-1]
+		}
+	}
+	return nil
+}
+
+func (c *rerunChooser) View() string {
+	title := c.run.Name
+	if c.job != nil {
+		title = c.job.Name
+	}
+
+	rows := []string{lipgloss.NewStyle().Bold(true).MarginBottom(1).Render("Rerun " + title)}
+	for i, option := range c.options {
+		row := fmt.Sprintf("%d %s", i+1, option.label)
+		if i == c.selected {
+			row = lipgloss.NewStyle().Foreground(c.styles.colors.focusedColor).Bold(true).
+				Render("> " + row)
+		} else {
+			row = "  " + row
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, c.styles.faintFgStyle.MarginTop(1).Render(
+		"j/k move • enter rerun • esc cancel"))
+
+	return c.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// applyRerunOption reruns the chooser's run or job the chosen way.
+func (m *model) applyRerunOption(c *rerunChooser, option rerunOption) []tea.Cmd {
+	switch option.action {
+	case rerunActionJob:
+		return m.rerunJob(c.run.Id, c.job.Id, option.debug)
+	case rerunActionFailedJobs:
+		return m.rerunFailedJobs(c.run, option.debug)
+	default:
+		return m.rerunRun(c.run, option.debug)
+	}
+}
+
+// makeRestartPollingCmd refetches the checks after they were rerun and keeps
+// polling them, as polling stops once all of them concluded.
+func (m *model) makeRestartPollingCmd() tea.Cmd {
+	switch m.mode() {
+	case ModeRun:
+		return m.fetchRunWithInterval()
+	case ModePR:
+		return m.fetchPRChecksWithInterval()
+	default:
+		return m.makeReconcileCmd()
+	}
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestRerunFailedJobsFromChooser(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:     "1",
+		Name:   "test",
+		Bucket: data.CheckBucketFail,
+		Jobs: []data.WorkflowJob{
+			{Id: "10", Name: "lint", Bucket: data.CheckBucketPass, Kind: data.JobKindGithubActions},
+			{
+				Id:         "11",
+				Name:       "unit",
+				State:      api.StatusCompleted,
+				Conclusion: api.ConclusionFailure,
+				Bucket:     data.CheckBucketFail,
+				Kind:       data.JobKindGithubActions,
+			},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	m = updated.(model)
+	if m.rerunChooser == nil {
+		t.Fatal("expected the rerun chooser to open")
+	}
+	labels := make([]string, 0)
+	for _, option := range m.rerunChooser.options {
+		labels = append(labels, option.label)
+	}
+	want := []string{
+		"Rerun failed jobs",
+		"Rerun failed jobs with debug logging",
+		"Rerun all jobs",
+		"Rerun all jobs with debug logging",
+	}
+	if !slices.Equal(labels, want) {
+		t.Fatalf("expected the options %v, got %v", want, labels)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(model)
+
+	if m.rerunChooser != nil {
+		t.Error("expected the rerun chooser to close")
+	}
+	if run := m.getSelectedRun(); run.Bucket != data.CheckBucketPending {
+		t.Errorf("expected the run to be marked as pending, got %s", run.Bucket)
+	}
+	if ji := m.getJobItemById("11"); ji.job.Bucket != data.CheckBucketPending ||
+		ji.job.State != api.StatusPending {
+		t.Errorf("expected the failed job to be marked as pending, got %+v", ji.job)
+	}
+	if ji := m.getJobItemById("10"); ji.job.Bucket != data.CheckBucketPass {
+		t.Errorf("expected the successful job to be left as is, got %s", ji.job.Bucket)
+	}
+}
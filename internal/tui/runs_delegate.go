@@ -25,6 +25,13 @@ type runItem struct {
 	loadingSteps   bool
 	lastFetchSteps time.Time
 	spinner        spinner.Model
+
+	// attempt is the previous attempt of the run whose jobs are shown, 0 for the latest
+	attempt int
+	// attempts is the number of attempts of the run, 0 until it's known
+	attempts int
+	// attemptRun is the run as it was in the shown previous attempt
+	attemptRun *data.WorkflowRun
 }
 
 // Title implements /charm.land/bubbles.list.DefaultItem.Title
@@ -60,7 +67,12 @@ func (i *runItem) Description() string {
 		}
 	}
 
-	return fmt.Sprintf("on %s%s", i.run.Event, startedAt)
+	attempt := ""
+	if latest := i.latestAttempt(); latest > 1 {
+		attempt = fmt.Sprintf(" · attempt %d/%d", i.shownAttempt(), latest)
+	}
+
+	return fmt.Sprintf("on %s%s%s", i.run.Event, startedAt, attempt)
 }
 
 // FilterValue implements /charm.land/bubbles.list.Item.FilterValue
@@ -90,18 +102,23 @@ func (i *runItem) HasNotConcluded() bool {
 }
 
 func (i *runItem) ShouldFetchJobs() bool {
+	// the jobs of previous attempts don't change, and would be replaced by the latest ones
+	if i.run.IsExternalStatuses() || i.attempt != 0 {
+		return false
+	}
 	return !i.loadingJobs &&
 		(i.lastFetchJobs.IsZero() || (time.Since(i.lastFetchJobs) > refreshInterval && i.HasNotConcluded()))
 }
 
 func (i *runItem) viewStatus() string {
 	s := i.meta.TitleStyle()
+	run := i.shownRun()
 
-	if i.run.Status == "in_progress" {
+	if run.Status == "in_progress" {
 		return i.spinner.View()
 	}
 
-	return bucketToIcon(i.run.Bucket, s, i.meta.styles)
+	return bucketToIcon(run.Bucket, s, i.meta.styles)
 }
 
 func (ri *runItem) Tick() tea.Cmd {
@@ -0,0 +1,383 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// searchOptions are the modifiers of the logs search, toggled while typing it
+type searchOptions struct {
+	regex         bool
+	caseSensitive bool
+	wholeWord     bool
+}
+
+// compileSearch returns the regexp matching what the search looks for with its options
+func compileSearch(query string, opts searchOptions) (*regexp.Regexp, error) {
+	pattern := query
+	if !opts.regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if opts.wholeWord {
+		pattern = `\b(?:` + pattern + `)\b`
+	}
+	if !opts.caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// findSearchMatches returns the matches in the lines as byte ranges of the lines joined
+// by newlines, as the viewport highlights them, along with the row of each match.
+// Empty matches, e.g. of `a*`, are skipped.
+func findSearchMatches(re *regexp.Regexp, lines []string) ([][]int, []int) {
+	highlights := make([][]int, 0)
+	rows := make([]int, 0)
+	offset := 0
+	for row, line := range lines {
+		for _, match := range re.FindAllStringIndex(line, -1) {
+			if match[0] == match[1] {
+				continue
+			}
+			highlights = append(highlights, []int{offset + match[0], offset + match[1]})
+			rows = append(rows, row)
+		}
+		offset += len(line) + 1
+	}
+	return highlights, rows
+}
+
+// nearestSearchMatch returns the first match at or below the row, like the viewport
+// does when it's scrolled, or -1 when all matches are above it
+func nearestSearchMatch(rows []int, row int) int {
+	i, _ := slices.BinarySearch(rows, row)
+	if i == len(rows) {
+		return -1
+	}
+	return i
+}
+
+// applySearch highlights the matches of the search in the logs viewport. The search
+// stays focused when it isn't a valid regex.
+func (m *model) applySearch() {
+	query := m.logsInput.Value()
+	if query == "" {
+		m.logsInput.Blur()
+		return
+	}
+
+	re, err := compileSearch(query, m.searchOpts)
+	if err != nil {
+		m.searchErr = err
+		return
+	}
+	m.searchErr = nil
+	m.saveSearch(query)
+
+	// matches in folded groups are shown
+	if ji := m.shownJobLogs(); ji != nil {
+		m.revealMatchingLines(ji, re.MatchString)
+	}
+	lines, ok := m.searchableLogs()
+	if !ok {
+		return
+	}
+
+	m.logsViewport.SetContentLines(lines)
+	m.logsViewport.ClearHighlights()
+	m.searchHighlights, m.searchRows = findSearchMatches(re, lines)
+	m.numHighlights = len(m.searchHighlights)
+	m.searchIdx = -1
+	if m.numHighlights > 0 {
+		m.logsViewport.SetHighlights(m.searchHighlights)
+		m.searchIdx = nearestSearchMatch(m.searchRows, m.logsViewport.YOffset())
+		if m.searchIdx == -1 {
+			m.logsViewport.HighlightNext()
+			m.searchIdx = 0
+		}
+	}
+	m.logsInput.Blur()
+}
+
+// resetSearchMatches forgets the matches of the applied search
+func (m *model) resetSearchMatches() {
+	m.numHighlights = 0
+	m.searchHighlights, m.searchRows = nil, nil
+	m.searchIdx = -1
+	m.searchErr = nil
+	m.searchMatches = nil
+}
+
+// cycleSearchMatch highlights the next (delta 1) or previous (delta -1) match
+func (m *model) cycleSearchMatch(delta int) {
+	if m.numHighlights == 0 {
+		return
+	}
+	if delta > 0 {
+		m.logsViewport.HighlightNext()
+	} else {
+		m.logsViewport.HighlightPrevious()
+	}
+	m.searchIdx = (m.searchIdx + delta + m.numHighlights) % m.numHighlights
+}
+
+// goToSearchMatch scrolls the logs to a match, keeping a few lines above it in view
+func (m *model) goToSearchMatch(i int) {
+	m.followLogs = false
+	m.logsViewport.SetYOffset(m.searchRows[i] - 3)
+	// the viewport highlights the first match below its top once they're set,
+	// the ones up to the chosen one are in view
+	m.logsViewport.SetHighlights(m.searchHighlights)
+	m.searchIdx = nearestSearchMatch(m.searchRows, m.logsViewport.YOffset())
+	for m.searchIdx < i {
+		m.cycleSearchMatch(1)
+	}
+}
+
+// updateSearchInput handles the keys pressed while typing a search, reporting whether
+// the key was handled
+func (m *model) updateSearchInput(msg tea.KeyPressMsg) bool {
+	switch {
+	case key.Matches(msg, toggleRegexKey):
+		m.searchOpts.regex = !m.searchOpts.regex
+	case key.Matches(msg, toggleCaseSensitiveKey):
+		m.searchOpts.caseSensitive = !m.searchOpts.caseSensitive
+	case key.Matches(msg, toggleWholeWordKey):
+		m.searchOpts.wholeWord = !m.searchOpts.wholeWord
+	case key.Matches(msg, prevSearchKey):
+		m.recallSearch(-1)
+	case key.Matches(msg, nextSearchKey):
+		m.recallSearch(1)
+	default:
+		return false
+	}
+	m.searchErr = nil
+	return true
+}
+
+// saveSearch adds a search to the session's history, moving it to the end when it
+// was searched before
+func (m *model) saveSearch(query string) {
+	m.searchHistory = slices.DeleteFunc(m.searchHistory, func(s string) bool { return s == query })
+	m.searchHistory = append(m.searchHistory, query)
+	m.searchHistoryIdx = len(m.searchHistory)
+}
+
+// recallSearch replaces the search with an older (delta -1) or newer (delta 1) one
+// from the history. Going past the newest one brings back what was being typed.
+func (m *model) recallSearch(delta int) {
+	i := m.searchHistoryIdx + delta
+	if i < 0 || i > len(m.searchHistory) {
+		return
+	}
+	if m.searchHistoryIdx == len(m.searchHistory) {
+		m.searchDraft = m.logsInput.Value()
+	}
+
+	m.searchHistoryIdx = i
+	if i == len(m.searchHistory) {
+		m.logsInput.SetValue(m.searchDraft)
+	} else {
+		m.logsInput.SetValue(m.searchHistory[i])
+	}
+	m.logsInput.CursorEnd()
+}
+
+// focusSearch starts typing a new search
+func (m *model) focusSearch() tea.Cmd {
+	m.searchHistoryIdx = len(m.searchHistory)
+	m.searchErr = nil
+	return m.logsInput.Focus()
+}
+
+// viewSearchOptions renders the modifiers of the search, the enabled ones highlighted
+func (m *model) viewSearchOptions() string {
+	on := lipgloss.NewStyle().Foreground(m.styles.colors.focusedColor).Bold(true)
+	off := m.styles.faintFgStyle
+	opts := []struct {
+		label   string
+		enabled bool
+	}{
+		{".*", m.searchOpts.regex},
+		{"Aa", m.searchOpts.caseSensitive},
+		{`\b`, m.searchOpts.wholeWord},
+	}
+
+	rendered := make([]string, 0, len(opts))
+	for _, opt := range opts {
+		s := off
+		if opt.enabled {
+			s = on
+		}
+		rendered = append(rendered, s.Render(opt.label))
+	}
+	return " " + strings.Join(rendered, " ") + " "
+}
+
+// viewSearchStatus renders the number of matches of the applied search and the one
+// highlighted, or why the search can't be applied
+func (m *model) viewSearchStatus() string {
+	if m.searchErr != nil {
+		return lipgloss.NewStyle().Foreground(m.styles.colors.errorColor).
+			Render("invalid regex: " + m.searchErr.Error())
+	}
+	if m.logsInput.Value() == "" || m.logsInput.Focused() {
+		return ""
+	}
+
+	matches := fmt.Sprintf("%d matches", m.numHighlights)
+	if m.numHighlights == 0 {
+		matches = "no matches"
+	} else if m.searchIdx >= 0 {
+		matches = fmt.Sprintf("%d/%d matches", m.searchIdx+1, m.numHighlights)
+	}
+	return m.styles.faintFgStyle.Render(matches)
+}
+
+// searchMatchContext is the number of lines shown around the selected match in the list
+const searchMatchContext = 2
+
+// maxSearchMatchRows is the number of matching lines listed at once
+const maxSearchMatchRows = 12
+
+// searchMatchEntry is a line of the logs viewport the search matches
+type searchMatchEntry struct {
+	row int
+	// match is the index of the line's first match
+	match int
+}
+
+// searchMatchList lists the lines the search matches, with the lines around the
+// selected one. The chosen line is shown by the caller.
+type searchMatchList struct {
+	query    string
+	re       *regexp.Regexp
+	lines    []string
+	numbers  []int // the numbers of the lines in the full logs, nil when they're numbered in order
+	entries  []searchMatchEntry
+	selected int
+	width    int
+	styles   styles
+}
+
+// openSearchMatches lists the lines matching the applied search
+func (m *model) openSearchMatches() {
+	if m.numHighlights == 0 {
+		return
+	}
+	re, err := compileSearch(m.logsInput.Value(), m.searchOpts)
+	if err != nil {
+		return
+	}
+	lines, ok := m.searchableLogs()
+	if !ok {
+		return
+	}
+
+	l := &searchMatchList{
+		query:  m.logsInput.Value(),
+		re:     re,
+		lines:  lines,
+		width:  max(40, min(m.width-10, 120)),
+		styles: m.styles,
+	}
+	if ji := m.shownJobLogs(); ji != nil {
+		l.numbers = ji.shownLines
+	}
+	for i, row := range m.searchRows {
+		if n := len(l.entries); n > 0 && l.entries[n-1].row == row {
+			continue
+		}
+		l.entries = append(l.entries, searchMatchEntry{row: row, match: i})
+		if i <= m.searchIdx {
+			l.selected = len(l.entries) - 1
+		}
+	}
+	m.searchMatches = l
+}
+
+// Update moves the selection, returning the chosen entry once enter is pressed
+func (l *searchMatchList) Update(msg tea.KeyPressMsg) *searchMatchEntry {
+	switch {
+	case key.Matches(msg, nextRowKey, nextFormFieldKey):
+		l.selected = min(l.selected+1, len(l.entries)-1)
+	case key.Matches(msg, prevRowKey, prevFormFieldKey):
+		l.selected = max(l.selected-1, 0)
+	case key.Matches(msg, gotoTopKey):
+		l.selected = 0
+	case key.Matches(msg, gotoBottomKey):
+		l.selected = len(l.entries) - 1
+	case key.Matches(msg, submitFormKey):
+		return &l.entries[l.selected]
+	}
+	return nil
+}
+
+func (l *searchMatchList) lineNumber(row int) int {
+	if row < len(l.numbers) {
+		return l.numbers[row] + 1
+	}
+	return row + 1
+}
+
+// renderLine renders a line prefixed by its number, with the matches highlighted
+func (l *searchMatchList) renderLine(row int, numberStyle lipgloss.Style, highlight bool) string {
+	line := l.lines[row]
+	if highlight {
+		matchStyle := lipgloss.NewStyle().Foreground(l.styles.tint.Black).Background(l.styles.tint.Blue)
+		rendered := strings.Builder{}
+		last := 0
+		for _, match := range l.re.FindAllStringIndex(line, -1) {
+			if match[0] == match[1] {
+				continue
+			}
+			rendered.WriteString(line[last:match[0]])
+			rendered.WriteString(matchStyle.Render(line[match[0]:match[1]]))
+			last = match[1]
+		}
+		rendered.WriteString(line[last:])
+		line = rendered.String()
+	} else {
+		line = l.styles.faintFgStyle.Render(line)
+	}
+
+	number := numberStyle.Render(fmt.Sprintf("%6d", l.lineNumber(row)))
+	return ansi.Truncate(number+" "+line, l.width, Ellipsis)
+}
+
+func (l *searchMatchList) View() string {
+	title := lipgloss.NewStyle().Bold(true).Render("Matches of " + l.query)
+	rows := []string{lipgloss.JoinHorizontal(lipgloss.Top, title, " ",
+		l.styles.faintFgStyle.Render(fmt.Sprintf("%d lines", len(l.entries))))}
+	rows = append(rows, "")
+
+	start := max(0, min(l.selected-maxSearchMatchRows/2, len(l.entries)-maxSearchMatchRows))
+	end := min(len(l.entries), start+maxSearchMatchRows)
+	focused := lipgloss.NewStyle().Foreground(l.styles.colors.focusedColor).Bold(true)
+	for i, entry := range l.entries[start:end] {
+		if start+i == l.selected {
+			rows = append(rows, focused.Render(">")+l.renderLine(entry.row, focused, true))
+		} else {
+			rows = append(rows, " "+l.renderLine(entry.row, l.styles.faintFgStyle, true))
+		}
+	}
+
+	// the lines around the selected match
+	rows = append(rows, "", l.styles.faintFgStyle.Render(strings.Repeat("─", l.width+1)))
+	selected := l.entries[l.selected].row
+	for row := max(0, selected-searchMatchContext); row <= min(len(l.lines)-1, selected+searchMatchContext); row++ {
+		rows = append(rows, " "+l.renderLine(row, l.styles.faintFgStyle, row == selected))
+	}
+
+	rows = append(rows, l.styles.faintFgStyle.MarginTop(1).Render(
+		"j/k move • enter go to line • esc close"))
+
+	return l.styles.popupStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+func TestSearchingLogs(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "test", State: api.StatusCompleted, Conclusion: api.ConclusionFailure,
+				Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	logs := parser.ParseJobLogs(strings.Join([]string{
+		"2025-06-01T10:00:00Z --- FAIL: TestFlaky (0.01s)",
+		"2025-06-01T10:00:00Z FAIL\tgithub.com/dlvhdr/gh-enhance/internal/tui\t0.123s",
+		"2025-06-01T10:00:00Z ok  \tgithub.com/dlvhdr/gh-enhance/internal/api\t0.456s",
+		"2025-06-01T10:00:00Z 1 failure",
+	}, "\n"))
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: logs})
+	m = updated.(model)
+	m.focusPane(PaneLogs)
+
+	press := func(msg tea.KeyPressMsg) {
+		updated, _ = m.Update(msg)
+		m = updated.(model)
+	}
+	search := func(query string, toggles ...rune) {
+		press(tea.KeyPressMsg{Code: '/', Text: "/"})
+		m.logsInput.SetValue(query)
+		for _, r := range toggles {
+			press(tea.KeyPressMsg{Code: r, Mod: tea.ModAlt})
+		}
+		press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	}
+
+	search("fail")
+	if m.numHighlights != 3 {
+		t.Errorf("expected the search to ignore case, got %d matches", m.numHighlights)
+	}
+	if status := ansi.Strip(m.viewSearchStatus()); status != "1/3 matches" {
+		t.Errorf("expected the first match to be highlighted, got %q", status)
+	}
+	press(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if status := ansi.Strip(m.viewSearchStatus()); status != "2/3 matches" {
+		t.Errorf("expected the next match to be highlighted, got %q", status)
+	}
+
+	search("fail", 'c')
+	if m.numHighlights != 1 {
+		t.Errorf("expected a case sensitive search, got %d matches", m.numHighlights)
+	}
+
+	search("fail", 'c', 'w')
+	if m.numHighlights != 2 {
+		t.Errorf("expected a whole word search, got %d matches", m.numHighlights)
+	}
+
+	search(`FAIL\s+\S+`, 'w', 'r')
+	if m.numHighlights != 1 || m.searchRows[0] != 1 {
+		t.Errorf("expected a regex search, got matches in rows %v", m.searchRows)
+	}
+
+	search("(")
+	if m.searchErr == nil || !m.logsInput.Focused() {
+		t.Errorf("expected an invalid regex to keep the search open, got err %v", m.searchErr)
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	// searches are recalled from the history
+	press(tea.KeyPressMsg{Code: '/', Text: "/"})
+	m.logsInput.SetValue("draft")
+	press(tea.KeyPressMsg{Code: tea.KeyUp})
+	if m.logsInput.Value() != `FAIL\s+\S+` {
+		t.Errorf("expected the latest search to be recalled, got %q", m.logsInput.Value())
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyUp})
+	if m.logsInput.Value() != "fail" {
+		t.Errorf("expected the previous search to be recalled, got %q", m.logsInput.Value())
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyDown})
+	press(tea.KeyPressMsg{Code: tea.KeyDown})
+	if m.logsInput.Value() != "draft" {
+		t.Errorf("expected the typed search to be brought back, got %q", m.logsInput.Value())
+	}
+
+	// the matching lines are listed, choosing one highlights it
+	m.searchOpts = searchOptions{}
+	m.logsInput.SetValue("fail")
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	press(tea.KeyPressMsg{Code: 'L', Text: "L"})
+	if m.searchMatches == nil || len(m.searchMatches.entries) != 3 {
+		t.Fatalf("expected the matching lines to be listed, got %+v", m.searchMatches)
+	}
+	if view := ansi.Strip(m.searchMatches.View()); !strings.Contains(view, "4 1 failure") {
+		t.Errorf("expected the lines to be numbered, got %q", view)
+	}
+	press(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	press(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.searchMatches != nil || m.searchIdx != 2 {
+		t.Errorf("expected the chosen line's match to be highlighted, got match %d", m.searchIdx)
+	}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestStatusContextsAreGroupedUnderExternalStatuses(t *testing.T) {
+	created := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	nodes := []api.ContextNode{
+		{Typename: "StatusContext", StatusContext: api.StatusContext{
+			Context:     "continuous-integration/jenkins/pr-merge",
+			Description: "This commit cannot be built",
+			State:       "FAILURE",
+			TargetUrl:   "https://jenkins.example.com/job/pr-merge/12/",
+			CreatedAt:   created,
+		}},
+		{Typename: "StatusContext", StatusContext: api.StatusContext{
+			Context:     "vercel",
+			Description: "Deployment has started",
+			State:       "PENDING",
+			TargetUrl:   "https://vercel.com/deployments/1",
+			CreatedAt:   created,
+		}},
+	}
+
+	runs := makeWorkflowRuns(nodes)
+	if len(runs) != 1 {
+		t.Fatalf("expected the statuses to be grouped under one run, got %d runs", len(runs))
+	}
+	run := runs[0]
+	if !run.IsExternalStatuses() || run.Name != "External statuses" || run.Bucket != data.CheckBucketFail {
+		t.Fatalf("unexpected run %+v", run)
+	}
+
+	jenkins := run.Jobs[0]
+	if jenkins.Name != "continuous-integration/jenkins/pr-merge" ||
+		jenkins.Kind != data.JobKindStatusContext || jenkins.Bucket != data.CheckBucketFail ||
+		jenkins.Link != "https://jenkins.example.com/job/pr-merge/12/" ||
+		jenkins.Title != "This commit cannot be built" {
+		t.Errorf("unexpected failed status %+v", jenkins)
+	}
+	if vercel := run.Jobs[1]; vercel.State != api.StatusPending || vercel.Bucket != data.CheckBucketPending {
+		t.Errorf("expected the pending status to be pending, got %+v", vercel)
+	}
+
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", PRNumber: "1"})
+	ji := NewJobItem(jenkins, m.styles)
+	output := m.makeRenderStatusContextCmd(&ji)().(checkRunOutputFetchedMsg)
+	if got := ansi.Strip(output.renderedText); !strings.Contains(got, "This commit cannot be built") ||
+		!strings.Contains(got, "failure") {
+		t.Errorf("expected the status' state and description to be rendered, got %q", got)
+	}
+}
+
+func TestRunActionsIgnoreExternalStatuses(t *testing.T) {
+	for _, run := range []data.WorkflowRun{
+		{
+			Id:     data.ExternalStatusesRunId,
+			Name:   "External statuses",
+			Bucket: data.CheckBucketPending,
+			Jobs: []data.WorkflowJob{
+				{Id: "vercel", Name: "vercel", Bucket: data.CheckBucketPending, Kind: data.JobKindStatusContext},
+			},
+		},
+		{
+			// a check suite of another app, identified by the suite's id rather than a run's
+			Id:     "42",
+			Name:   "Buildkite",
+			Bucket: data.CheckBucketPending,
+			Jobs: []data.WorkflowJob{
+				{Id: "7", Name: "build", State: api.StatusInProgress, Bucket: data.CheckBucketPending,
+					Kind: data.JobKindExternal},
+			},
+		},
+	} {
+		m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: run.Id})
+		updated, _ := m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{run}})
+		m = updated.(model)
+
+		for _, msg := range []tea.KeyPressMsg{
+			{Code: 'D', Text: "D"},
+			{Code: 'A', Text: "A"},
+			{Code: 'r', Mod: tea.ModCtrl},
+			{Code: 'x', Mod: tea.ModCtrl},
+		} {
+			updated, _ = m.Update(msg)
+			m = updated.(model)
+		}
+
+		if m.deploymentReview != nil || m.artifactsBrowser != nil || m.rerunChooser != nil {
+			t.Errorf("expected no popup to be opened for %s", run.Name)
+		}
+		if got := m.getSelectedRun().Bucket; got != data.CheckBucketPending {
+			t.Errorf("expected %s not to be canceled, got %s", run.Name, got)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+// stepLogs is the range of log lines a step wrote, from start to end excluded
+type stepLogs struct {
+	start, end int
+}
+
+// stepBoundary is a log line where a step may start, with the title the runner wrote for it
+type stepBoundary struct {
+	line  int
+	title string
+}
+
+// setUpJobStep is the first step of every job, its logs start at the first line
+const setUpJobStep = "Set up job"
+
+// parseStepBoundaries returns the lines where steps may start: the first line, the
+// ##[group]Run lines that aren't nested in a group, and the lines post steps and the
+// job's completion start with.
+func parseStepBoundaries(logs []data.LogsWithTime) []stepBoundary {
+	boundaries := make([]stepBoundary, 0)
+	for i, l := range logs {
+		switch {
+		case l.Kind == data.LogKindStepStart && l.Depth == 1:
+			_, title, _ := strings.Cut(ansi.Strip(l.Log), parser.GroupStartMarker)
+			boundaries = append(boundaries, stepBoundary{line: i, title: strings.TrimSpace(title)})
+		case l.Kind == data.LogKindJobCleanup:
+			boundaries = append(boundaries, stepBoundary{line: i, title: parser.PostJobCleanupMarker})
+		case strings.Contains(l.Log, parser.CompleteJobMarker):
+			boundaries = append(boundaries, stepBoundary{line: i, title: parser.CompleteJobMarker})
+		case i == 0:
+			boundaries = append(boundaries, stepBoundary{line: i, title: setUpJobStep})
+		}
+	}
+	return boundaries
+}
+
+// stepMatchesBoundary reports whether the runner starts the step's logs with the boundary's title.
+// Steps are titled "Run <command>" or "Run <action>" in the logs unless they were given a name.
+func stepMatchesBoundary(step api.Step, b stepBoundary) bool {
+	switch {
+	case step.Name == "Complete job":
+		return b.title == parser.CompleteJobMarker
+	case strings.HasPrefix(step.Name, "Post "):
+		return b.title == parser.PostJobCleanupMarker
+	default:
+		return b.title == strings.TrimSpace(step.Name)
+	}
+}
+
+// mapStepsToLogs returns the lines each step wrote, keyed by the step's number.
+// Steps are first matched to the boundaries titled after them, the steps that were
+// given a name then take the boundaries left between their neighbours.
+func mapStepsToLogs(logs []data.LogsWithTime, steps []api.Step) map[int]stepLogs {
+	steps = slices.Clone(steps)
+	slices.SortFunc(steps, func(a, b api.Step) int { return a.Number - b.Number })
+	// skipped steps write no logs
+	steps = slices.DeleteFunc(steps, func(s api.Step) bool {
+		return s.Conclusion == api.ConclusionSkipped
+	})
+
+	boundaries := parseStepBoundaries(logs)
+	assigned := make([]int, len(steps))
+	next := 0
+	for i, step := range steps {
+		assigned[i] = -1
+		for b := next; b < len(boundaries); b++ {
+			if stepMatchesBoundary(step, boundaries[b]) {
+				assigned[i] = b
+				next = b + 1
+				break
+			}
+		}
+	}
+
+	prev := -1
+	for i := range steps {
+		if assigned[i] == -1 {
+			limit := len(boundaries)
+			for j := i + 1; j < len(steps); j++ {
+				if assigned[j] != -1 {
+					limit = assigned[j]
+					break
+				}
+			}
+			if prev+1 < limit {
+				assigned[i] = prev + 1
+			}
+		}
+		if assigned[i] != -1 {
+			prev = assigned[i]
+		}
+	}
+
+	mapped := make(map[int]stepLogs, len(steps))
+	for i, step := range steps {
+		if assigned[i] == -1 {
+			continue
+		}
+		end := len(logs)
+		for j := i + 1; j < len(steps); j++ {
+			if assigned[j] != -1 {
+				end = boundaries[assigned[j]].line
+				break
+			}
+		}
+		mapped[step.Number] = stepLogs{start: boundaries[assigned[i]].line, end: end}
+	}
+	return mapped
+}
+
+// stepsLogs maps the job's steps to the lines of its logs. The mapping is kept until
+// the logs or steps change, as it's needed on every scroll of the logs.
+func (ji *jobItem) stepsLogs() map[int]stepLogs {
+	if ji.stepsLines != nil {
+		return ji.stepsLines
+	}
+	steps := make([]api.Step, 0, len(ji.steps))
+	for _, si := range ji.steps {
+		steps = append(steps, *si.step)
+	}
+	ji.stepsLines = mapStepsToLogs(ji.logs, steps)
+	return ji.stepsLines
+}
+
+// selectedStepLogs returns the lines of the job's logs the selected step wrote
+func (m *model) selectedStepLogs(ji *jobItem) (stepLogs, bool) {
+	step := m.stepsList.SelectedItem()
+	if step == nil {
+		return stepLogs{}, false
+	}
+	r, ok := ji.stepsLogs()[step.(*stepItem).step.Number]
+	return r, ok
+}
+
+// stepOnlyLogs returns the lines the logs viewport is restricted to when showing
+// the selected step only
+func (m *model) stepOnlyLogs(ji *jobItem) (stepLogs, bool) {
+	if !m.stepOnly {
+		return stepLogs{}, false
+	}
+	return m.selectedStepLogs(ji)
+}
+
+// selectStepOfLine selects the step that wrote a line of the job's logs without
+// scrolling the logs, and reports whether the selected step changed
+func (m *model) selectStepOfLine(ji *jobItem, line int) bool {
+	for number, r := range ji.stepsLogs() {
+		if line < r.start || line >= r.end {
+			continue
+		}
+		if step := m.stepsList.SelectedItem(); step != nil && step.(*stepItem).step.Number == number {
+			return false
+		}
+		for i, item := range m.stepsList.VisibleItems() {
+			if item.(*stepItem).step.Number == number {
+				m.stepsList.Select(i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// syncStepWithLogs selects the step of the line at the top of the logs viewport,
+// so the steps pane follows the logs as they're scrolled
+func (m *model) syncStepWithLogs() {
+	ji := m.shownJobLogs()
+	if ji == nil || m.stepOnly || len(ji.shownLines) == 0 {
+		return
+	}
+	m.selectStepOfLine(ji, ji.shownLines[min(m.logsViewport.YOffset(), len(ji.shownLines)-1)])
+}
+
+// toggleStepOnly restricts the logs viewport to the lines of the selected step, or
+// shows all of the job's logs again
+func (m *model) toggleStepOnly() {
+	ji := m.shownJobLogs()
+	if ji == nil {
+		return
+	}
+	if _, ok := m.selectedStepLogs(ji); !ok && !m.stepOnly {
+		return
+	}
+
+	m.clearSearch()
+	m.stepOnly = !m.stepOnly
+	m.setJobLogsContent(ji)
+	if r, ok := m.selectedStepLogs(ji); ok {
+		m.logsViewport.SetYOffset(ji.rowOfLine(r.start))
+	}
+}
+
+// viewStepOnlyHint renders the name of the step the logs are restricted to
+func (m *model) viewStepOnlyHint() string {
+	ji := m.shownJobLogs()
+	if ji == nil {
+		return ""
+	}
+	if _, ok := m.stepOnlyLogs(ji); !ok {
+		return ""
+	}
+	return m.styles.faintFgStyle.Render("only " + m.stepsList.SelectedItem().(*stepItem).step.Name +
+		", S to show all steps")
+}
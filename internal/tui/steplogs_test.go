@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+func stepsTestLogs(testOutputLines int) []data.LogsWithTime {
+	lines := []string{
+		"2025-06-01T10:00:00Z Current runner version: '2.325.0'",
+		"2025-06-01T10:00:00Z ##[group]Runner Image",
+		"2025-06-01T10:00:00Z Image: ubuntu-24.04",
+		"2025-06-01T10:00:00Z ##[endgroup]",
+		"2025-06-01T10:00:01Z ##[group]Run actions/checkout@v4",
+		"2025-06-01T10:00:01Z with:",
+		"2025-06-01T10:00:01Z ##[endgroup]",
+		"2025-06-01T10:00:02Z Syncing repository: dlvhdr/gh-enhance",
+		"2025-06-01T10:00:03Z ##[group]Run go test ./...",
+		"2025-06-01T10:00:03Z go test ./...",
+		"2025-06-01T10:00:03Z ##[endgroup]",
+	}
+	for i := range testOutputLines {
+		lines = append(lines, fmt.Sprintf("2025-06-01T10:00:04Z ok  \tgithub.com/dlvhdr/gh-enhance/internal/pkg%d\t0.1s", i))
+	}
+	lines = append(lines,
+		"2025-06-01T10:00:05Z Post job cleanup.",
+		"2025-06-01T10:00:05Z [command]/usr/bin/git version",
+		"2025-06-01T10:00:06Z Cleaning up orphan processes",
+	)
+	return parser.ParseJobLogs(strings.Join(lines, "\n"))
+}
+
+func TestMapStepsToLogs(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []api.Step
+		want  map[int]stepLogs
+	}{
+		{
+			name: "default and custom step names",
+			steps: []api.Step{
+				{Number: 1, Name: "Set up job"},
+				{Number: 2, Name: "Checkout"},
+				{Number: 3, Name: "Run go test ./..."},
+				{Number: 4, Name: "Post Checkout"},
+				{Number: 5, Name: "Complete job"},
+			},
+			want: map[int]stepLogs{1: {0, 4}, 2: {4, 8}, 3: {8, 13}, 4: {13, 15}, 5: {15, 16}},
+		},
+		{
+			name: "skipped steps",
+			steps: []api.Step{
+				{Number: 1, Name: "Set up job"},
+				{Number: 2, Name: "Checkout"},
+				{Number: 3, Name: "Lint", Conclusion: api.ConclusionSkipped},
+				{Number: 4, Name: "Test"},
+				{Number: 5, Name: "Post Checkout"},
+				{Number: 6, Name: "Complete job"},
+			},
+			want: map[int]stepLogs{1: {0, 4}, 2: {4, 8}, 4: {8, 13}, 5: {13, 15}, 6: {15, 16}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapStepsToLogs(stepsTestLogs(2), tt.steps); !maps.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNavigatingStepsAndLogs(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "test", State: api.StatusCompleted, Conclusion: api.ConclusionSuccess,
+				Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	steps := api.WorkflowRunStepsQuery{}
+	check := api.CheckRunWithSteps{DatabaseId: 11}
+	check.Steps.Nodes = []api.Step{
+		{Number: 1, Name: "Set up job"},
+		{Number: 2, Name: "Checkout"},
+		{Number: 3, Name: "Run go test ./..."},
+		{Number: 4, Name: "Post Checkout"},
+		{Number: 5, Name: "Complete job"},
+	}
+	steps.Resource.WorkflowRun.CheckSuite.CheckRuns.Nodes = []api.CheckRunWithSteps{check}
+	updated, _ = m.Update(workflowRunStepsFetchedMsg{runId: "1", data: steps})
+	m = updated.(model)
+
+	logs := stepsTestLogs(100)
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: logs})
+	m = updated.(model)
+	ji := m.getSelectedJobItem()
+
+	// selecting a step scrolls the logs to its first line
+	m.focusPane(PaneSteps)
+	m.stepsList.Select(0)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m = updated.(model)
+	if got := ji.shownLines[m.logsViewport.YOffset()]; got != 4 {
+		t.Errorf("expected the logs to scroll to the checkout step, got line %d at the top", got)
+	}
+
+	// showing the selected step only
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'S', Text: "S"})
+	m = updated.(model)
+	if want := []int{4, 7}; !slices.Equal(ji.shownLines, want) {
+		t.Errorf("expected only the checkout step's lines, got %v", ji.shownLines)
+	}
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m = updated.(model)
+	if ji.shownLines[0] != 8 || ji.shownLines[len(ji.shownLines)-1] != 110 {
+		t.Errorf("expected only the test step's lines, got %v", ji.shownLines)
+	}
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'S', Text: "S"})
+	m = updated.(model)
+	if len(ji.shownLines) != 108 {
+		t.Errorf("expected all steps to be shown again, got %d lines", len(ji.shownLines))
+	}
+
+	// scrolling the logs selects the step at the top
+	m.focusPane(PaneLogs)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	m = updated.(model)
+	if got := m.stepsList.SelectedItem().(*stepItem).step.Number; got != 1 {
+		t.Errorf("expected the first step to be selected at the top of the logs, got %d", got)
+	}
+}
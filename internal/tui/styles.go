@@ -65,6 +65,7 @@ type styles struct {
 	failureGlyph               lipgloss.Style
 	successGlyph               lipgloss.Style
 	warningGlyph               lipgloss.Style
+	noticeGlyph                lipgloss.Style
 	mergedGlyph                lipgloss.Style
 	draftGlyph                 lipgloss.Style
 	closedGlyph                lipgloss.Style
@@ -75,6 +76,11 @@ type styles struct {
 	errorBgStyle               lipgloss.Style
 	errorStyle                 lipgloss.Style
 	errorTitleStyle            lipgloss.Style
+	warningBgStyle             lipgloss.Style
+	warningStyle               lipgloss.Style
+	warningTitleStyle          lipgloss.Style
+	noticeTitleStyle           lipgloss.Style
+	debugLogStyle              lipgloss.Style
 	separatorStyle             lipgloss.Style
 	commandStyle               lipgloss.Style
 	stepStartMarkerStyle       lipgloss.Style
@@ -90,6 +96,7 @@ type styles struct {
 	footerStyle     lipgloss.Style
 	helpButtonStyle lipgloss.Style
 	helpPaneStyle   lipgloss.Style
+	popupStyle      lipgloss.Style
 }
 
 func makeStyles() styles {
@@ -117,6 +124,7 @@ func makeStyles() styles {
 	}
 
 	errorBgStyle := lipgloss.NewStyle().Background(lipgloss.Darken(t.Red, 0.8))
+	warningBgStyle := lipgloss.NewStyle().Background(lipgloss.Darken(t.Yellow, 0.8))
 	bg := lipgloss.Darken(t.Bg, 0.4)
 	brighterBg := lipgloss.Darken(t.Bg, 0.1)
 	unfocusedBg := lipgloss.Darken(focusedColor, 0.5)
@@ -149,6 +157,8 @@ func makeStyles() styles {
 			lipgloss.NormalBorder(),
 			true, false, false, false).
 			BorderForeground(colors.fainterColor),
+		popupStyle: lipgloss.NewStyle().Padding(1, 2).Border(lipgloss.RoundedBorder()).
+			BorderForeground(colors.focusedColor),
 
 		focusedPaneTitleStyle:      baseTitleStyle.Foreground(t.Black),
 		unfocusedPaneTitleStyle:    baseTitleStyle.Foreground(t.Fg),
@@ -224,6 +234,9 @@ func makeStyles() styles {
 		warningGlyph: lipgloss.NewStyle().
 			Foreground(colors.warnColor).
 			SetString(WarningIcon),
+		noticeGlyph: lipgloss.NewStyle().
+			Foreground(t.Blue).
+			SetString(NoticeIcon),
 		mergedGlyph: lipgloss.NewStyle().
 			Foreground(colors.mergedColor).
 			SetString(MergedIcon),
@@ -240,6 +253,11 @@ func makeStyles() styles {
 		errorBgStyle:               errorBgStyle,
 		errorStyle:                 errorBgStyle.Foreground(colors.errorColor).Bold(false),
 		errorTitleStyle:            errorBgStyle.Foreground(colors.errorColor).Bold(true),
+		warningBgStyle:             warningBgStyle,
+		warningStyle:               warningBgStyle.Foreground(colors.warnColor).Bold(false),
+		warningTitleStyle:          warningBgStyle.Foreground(colors.warnColor).Bold(true),
+		noticeTitleStyle:           lipgloss.NewStyle().Foreground(t.Blue).Bold(true).Inline(true),
+		debugLogStyle:              lipgloss.NewStyle().Foreground(colors.faintColor).Inline(true),
 		separatorStyle:             lipgloss.NewStyle().Foreground(colors.fainterColor),
 		commandStyle:               lipgloss.NewStyle().Foreground(t.Blue).Inline(true),
 		stepStartMarkerStyle:       lipgloss.NewStyle().Bold(true).Inline(true),
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/config"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+// logsTab is what the logs pane shows for GitHub Actions jobs
+type logsTab int
+
+const (
+	logsTabLogs    logsTab = iota
+	logsTabSummary         // the markdown the job wrote to GITHUB_STEP_SUMMARY
+)
+
+type jobSummaryFetchedMsg struct {
+	jobId    string
+	summary  string
+	rendered string
+	err      error
+}
+
+// makeFetchJobSummaryCmd fetches the summary of a completed GitHub Actions job. Summaries
+// are only fetched with FF_JOB_SUMMARIES set, see api.FetchJobSummary.
+func (m *model) makeFetchJobSummaryCmd(ji *jobItem) tea.Cmd {
+	if !config.IsFeatureEnabled(config.FF_JOB_SUMMARIES) || ji.fetchedSummary || ji.isStatusInProgress() || ji.job.Kind != data.JobKindGithubActions ||
+		ji.job.Title != "" {
+		return nil
+	}
+
+	runId := m.runIdOfJob(ji.job.Id)
+	if runId == "" {
+		return nil
+	}
+
+	ji.fetchedSummary = true
+	jobId := ji.job.Id
+	width := m.logsWidth()
+	return func() tea.Msg {
+		summary, err := m.client.FetchJobSummary(m.repo, runId, jobId)
+		if err != nil {
+			log.Warn("error fetching job summary", "jobId", jobId, "err", err)
+			return jobSummaryFetchedMsg{jobId: jobId, err: err}
+		}
+		if strings.TrimSpace(summary) == "" {
+			return jobSummaryFetchedMsg{jobId: jobId}
+		}
+
+		rendered, err := parser.ParseRunOutputMarkdown(summary, width)
+		if err != nil {
+			log.Error("failed rendering the job summary as markdown", "jobId", jobId, "err", err)
+			rendered = summary
+		}
+		return jobSummaryFetchedMsg{jobId: jobId, summary: summary, rendered: rendered}
+	}
+}
+
+func (m *model) runIdOfJob(jobId string) string {
+	for _, run := range m.workflowRuns {
+		for _, job := range run.Jobs {
+			if job.Id == jobId {
+				return run.Id
+			}
+		}
+	}
+
+	// jobs of previous attempts are only part of the run item showing them
+	for _, item := range m.runsList.Items() {
+		ri := item.(*runItem)
+		for _, ji := range ri.jobsItems {
+			if ji.job.Id == jobId {
+				return ri.run.Id
+			}
+		}
+	}
+	return ""
+}
+
+func (m *model) onJobSummaryFetched(msg jobSummaryFetchedMsg) tea.Cmd {
+	ji := m.getJobItemById(msg.jobId)
+	if ji == nil {
+		return nil
+	}
+	ji.summaryErr = msg.err
+	if msg.summary == "" {
+		return nil
+	}
+
+	ji.summary = msg.summary
+	ji.renderedSummary = msg.rendered
+	if curr := m.getSelectedJobItem(); curr == nil || curr.job.Id != msg.jobId ||
+		m.logsTab != logsTabSummary {
+		return nil
+	}
+	return m.renderJobLogs()
+}
+
+// hasSummary reports whether the selected job wrote a step summary
+func (m *model) hasSummary() bool {
+	ji := m.getSelectedJobItem()
+	return ji != nil && ji.renderedSummary != ""
+}
+
+// showingSummary reports whether the logs pane shows the selected job's summary
+func (m *model) showingSummary() bool {
+	return m.artifactPreview == nil && m.logsTab == logsTabSummary && m.hasSummary()
+}
+
+// toggleLogsTab switches between the logs and the summary of the selected job
+func (m *model) toggleLogsTab() tea.Cmd {
+	if !m.hasSummary() {
+		return nil
+	}
+
+	m.clearSearch()
+	if m.logsTab == logsTabSummary {
+		m.logsTab = logsTabLogs
+		// logs fetched while the summary was shown weren't rendered
+		if ji := m.getSelectedJobItem(); ji != nil {
+			ji.renderedLogs, ji.unstyledLogs = nil, nil
+		}
+		cmd := m.renderJobLogs()
+		m.goToErrorInLogs()
+		return cmd
+	}
+
+	m.logsTab = logsTabSummary
+	cmd := m.renderJobLogs()
+	m.logsViewport.GotoTop()
+	return cmd
+}
+
+func (m *model) summaryLines() []string {
+	return strings.Split(ansi.Strip(m.getSelectedJobItem().renderedSummary), "\n")
+}
+
+// viewLogsTabs renders the titles of the logs and summary tabs, the active one as a pill
+func (m *model) viewLogsTabs() string {
+	tabs := []string{"Job Logs", "Summary"}
+	active := 0
+	if m.showingSummary() {
+		active = 1
+	}
+
+	pillStyle, pillColor := m.styles.unfocusedPaneTitleStyle, m.styles.colors.unfocusedColor
+	barStyle := m.styles.unfocusedPaneTitleBarStyle
+	if m.focusedPane == PaneLogs {
+		pillStyle, pillColor = m.styles.focusedPaneTitleStyle, m.styles.colors.focusedColor
+		barStyle = m.styles.focusedPaneTitleBarStyle
+	}
+
+	rendered := make([]string, 0, len(tabs))
+	for i, tab := range tabs {
+		if i == active {
+			rendered = append(rendered, makePill(tab, pillStyle, pillColor))
+		} else {
+			rendered = append(rendered, m.styles.faintFgStyle.Padding(0, 1).Render(tab))
+		}
+	}
+	rendered = append(rendered, m.styles.faintFgStyle.Render(" s to switch"))
+	return barStyle.MarginBottom(0).Render(lipgloss.JoinHorizontal(lipgloss.Top, rendered...))
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/config"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestSwitchingToJobSummary(t *testing.T) {
+	t.Setenv(config.FF_JOB_SUMMARIES, "1")
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "bench", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+	if ji := m.getJobItemById("11"); !ji.fetchedSummary {
+		t.Error("expected the summary of the selected job to be fetched")
+	}
+
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: []data.LogsWithTime{{Log: "running benchmarks"}}})
+	m = updated.(model)
+	updated, _ = m.Update(jobSummaryFetchedMsg{
+		jobId:    "11",
+		summary:  "## Benchmarks",
+		rendered: "Benchmarks\nparse 12ns/op",
+	})
+	m = updated.(model)
+	if m.showingSummary() {
+		t.Fatal("expected the logs to stay shown until switching to the summary")
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	m = updated.(model)
+	if got := m.logsViewport.GetContent(); !strings.Contains(got, "parse 12ns/op") {
+		t.Errorf("expected the summary to be shown, got %q", got)
+	}
+	if view := ansi.Strip(m.viewLogs()); !strings.Contains(view, "Summary") {
+		t.Errorf("expected the summary tab in the logs title, got %q", view)
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	m = updated.(model)
+	if got := ansi.Strip(m.logsViewport.GetContent()); !strings.Contains(got, "running benchmarks") {
+		t.Errorf("expected the logs to be shown again, got %q", got)
+	}
+}
+
+func TestUnavailableJobSummary(t *testing.T) {
+	t.Setenv(config.FF_JOB_SUMMARIES, "1")
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "bench", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	updated, _ = m.Update(jobSummaryFetchedMsg{
+		jobId: "11",
+		err:   fmt.Errorf("%w: 404 Not Found", api.ErrSummaryUnavailable),
+	})
+	m = updated.(model)
+	if m.hasSummary() {
+		t.Error("expected no summary tab without a summary")
+	}
+	if view := ansi.Strip(m.viewLogs()); !strings.Contains(view, "summary unavailable: 404 Not Found") {
+		t.Errorf("expected the logs title to say the summary is unavailable, got %q", view)
+	}
+}
+
+func TestJobSummariesAreOptIn(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "bench", State: api.StatusCompleted, Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	if ji := m.getJobItemById("11"); ji.fetchedSummary {
+		t.Error("expected the summary not to be fetched without FF_JOB_SUMMARIES")
+	}
+	if view := ansi.Strip(m.viewLogs()); strings.Contains(view, "summary") {
+		t.Errorf("expected no summary in the logs title, got %q", view)
+	}
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"charm.land/bubbles/v2/viewport"
+	"charm.land/lipgloss/v2"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// gutterMode is what the gutter of the logs viewport shows next to each line
+type gutterMode int
+
+const (
+	gutterLineNumbers gutterMode = iota
+	gutterTime                   // the time the line was written at
+	gutterStepElapsed            // the time since its step started
+	gutterDelta                  // the time since the previous line
+	numGutterModes
+)
+
+// slowLineThreshold is the time after the previous line from which a line is highlighted,
+// to spot where a job hung or spent its time
+const slowLineThreshold = 10 * time.Second
+
+func (mode gutterMode) String() string {
+	switch mode {
+	case gutterTime:
+		return "time"
+	case gutterStepElapsed:
+		return "since step start"
+	case gutterDelta:
+		return "since previous line"
+	default:
+		return "line numbers"
+	}
+}
+
+// cycleGutterMode switches the gutter of the logs to its next mode
+func (m *model) cycleGutterMode() {
+	m.gutterMode = (m.gutterMode + 1) % numGutterModes
+	if ji := m.shownJobLogs(); ji != nil {
+		m.logsViewport.LeftGutterFunc = m.newJobLogsGutter(ji)
+	}
+}
+
+// newJobLogsGutter returns the gutter of the job's logs for the current gutter mode
+func (m *model) newJobLogsGutter(ji *jobItem) viewport.GutterFunc {
+	if m.gutterMode == gutterLineNumbers {
+		return newLogsGutter(m.styles, ji.shownLines)
+	}
+
+	times := logTimes(ji.logs, m.gutterMode)
+	end := len(ji.logs)
+	if r, ok := m.stepOnlyLogs(ji); ok {
+		end = min(r.end, end)
+	}
+	slow := slowRows(ji.logs, ji.shownLines, end)
+	faint := lipgloss.NewStyle().Foreground(m.styles.colors.faintColor)
+	highlight := lipgloss.NewStyle().Foreground(m.styles.colors.warnColor).Bold(true)
+	sep := lipgloss.NewStyle().Foreground(m.styles.colors.fainterColor).Render("│")
+	return func(info viewport.GutterContext) string {
+		t := ""
+		s := faint
+		if info.Index < len(ji.shownLines) && !info.Soft {
+			t = times[ji.shownLines[info.Index]]
+			if slow[info.Index] {
+				s = highlight
+			}
+		}
+		return s.Render(fmt.Sprintf(" %8s ", t)) + sep + " "
+	}
+}
+
+// logTimes formats the time of each log line for the gutter mode. Lines without a
+// time are left blank.
+func logTimes(logs []data.LogsWithTime, mode gutterMode) []string {
+	times := make([]string, len(logs))
+	var stepStart, prev time.Time
+	for i, l := range logs {
+		if l.Kind == data.LogKindStepStart {
+			stepStart = l.Time
+		}
+		if l.Time.IsZero() {
+			continue
+		}
+
+		switch mode {
+		case gutterTime:
+			times[i] = l.Time.Local().Format(time.TimeOnly)
+		case gutterStepElapsed:
+			if !stepStart.IsZero() {
+				times[i] = formatGutterDuration(l.Time.Sub(stepStart))
+			}
+		case gutterDelta:
+			if !prev.IsZero() {
+				times[i] = "+" + formatGutterDuration(l.Time.Sub(prev))
+			}
+		}
+		prev = l.Time
+	}
+	return times
+}
+
+// slowRows reports for each shown row whether its line, or one of the lines its folded
+// group hides, was written more than slowLineThreshold after the previous line. The
+// shown lines end before end, e.g. at the end of the step when showing a single one.
+func slowRows(logs []data.LogsWithTime, shownLines []int, end int) []bool {
+	slow := make([]bool, len(shownLines))
+	for row, line := range shownLines {
+		next := end
+		if row+1 < len(shownLines) {
+			next = shownLines[row+1]
+		}
+		for i := max(1, line); i < next; i++ {
+			if !logs[i-1].Time.IsZero() && logs[i].Time.Sub(logs[i-1].Time) > slowLineThreshold {
+				slow[row] = true
+				break
+			}
+		}
+	}
+	return slow
+}
+
+// formatGutterDuration formats a duration to fit the gutter, e.g. 0.25s, 12.3s or 1m05s
+func formatGutterDuration(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < 10*time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
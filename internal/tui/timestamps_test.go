@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/api"
+	"github.com/dlvhdr/gh-enhance/internal/data"
+	"github.com/dlvhdr/gh-enhance/internal/parser"
+)
+
+func TestCyclingLogsGutterModes(t *testing.T) {
+	m := NewModel(ModelOpts{Repo: "neovim/neovim", RunID: "1"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(model)
+	updated, _ = m.Update(runModeFetchedMsg{runs: []data.WorkflowRun{{
+		Id:   "1",
+		Name: "test",
+		Jobs: []data.WorkflowJob{
+			{Id: "11", Name: "test", State: api.StatusCompleted, Conclusion: api.ConclusionSuccess,
+				Kind: data.JobKindGithubActions},
+		},
+	}}})
+	m = updated.(model)
+
+	logs := parser.ParseJobLogs(strings.Join([]string{
+		"2025-06-01T10:00:00Z ##[group]Run go test ./...",
+		"2025-06-01T10:00:00Z go test ./...",
+		"2025-06-01T10:00:00Z ##[endgroup]",
+		"2025-06-01T10:00:01.5Z ok  \tgithub.com/dlvhdr/gh-enhance/internal/api\t0.4s",
+		"2025-06-01T10:01:31Z ok  \tgithub.com/dlvhdr/gh-enhance/internal/tui\t90s",
+	}, "\n"))
+	updated, _ = m.Update(jobLogsFetchedMsg{jobId: "11", logs: logs})
+	m = updated.(model)
+	m.focusPane(PaneLogs)
+	m.logsViewport.SetYOffset(0)
+
+	press := func() {
+		updated, _ = m.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+		m = updated.(model)
+	}
+
+	press()
+	if m.gutterMode != gutterTime {
+		t.Fatalf("expected the gutter to show the time, got %s", m.gutterMode)
+	}
+
+	press()
+	view := ansi.Strip(m.logsViewport.View())
+	if !strings.Contains(view, "1.50s │ ok") || !strings.Contains(view, "1m31s │ ok") {
+		t.Errorf("expected the time since the step started, got %q", view)
+	}
+
+	press()
+	view = ansi.Strip(m.logsViewport.View())
+	if !strings.Contains(view, "+1.50s │ ok") || !strings.Contains(view, "+1m29s │ ok") {
+		t.Errorf("expected the time since the previous line, got %q", view)
+	}
+	if slow := slowRows(logs, m.getSelectedJobItem().shownLines, len(logs)); !slices.Equal(slow, []bool{false, false, true}) {
+		t.Errorf("expected only the line after a pause to be slow, got %v", slow)
+	}
+	// the last shown line doesn't take the pause after it from the lines left out
+	if slow := slowRows(logs, []int{0, 1}, 2); !slices.Equal(slow, []bool{false, false}) {
+		t.Errorf("expected the lines after the shown range to be left out, got %v", slow)
+	}
+
+	press()
+	if m.gutterMode != gutterLineNumbers {
+		t.Errorf("expected the gutter to cycle back to line numbers, got %s", m.gutterMode)
+	}
+}
@@ -1,11 +1,11 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"math"
 	"os"
-	"regexp"
 	"runtime/debug"
 	"strings"
 	"time"
@@ -44,6 +44,7 @@ const (
 	PaneSteps
 	PaneChecks
 	PaneLogs
+	PaneAnnotations
 )
 
 type model struct {
@@ -63,6 +64,8 @@ type model struct {
 	checksList        list.Model
 	logsViewport      viewport.Model
 	numHighlights     int
+	annotationsCursor int
+	logsTab           logsTab
 	scrollbar         util.Model
 	focusedPane       pane
 	zoomedPane        *pane
@@ -80,15 +83,47 @@ type model struct {
 	version           string
 	rateLimit         api.RateLimit
 	lastFetched       time.Time
-	helpOpen          bool
-	help              help.Model
+	// repo mode pagination, repoRunsPage is the last page loaded
+	repoRunsPage       int
+	repoRunsTotalCount int
+	loadingMoreRuns    bool
+	repoFilters        api.RepoWorkflowRunsFilters
+	followLogs         bool              // keep the logs of a running job scrolled to the bottom as they come in
+	filtersForm        *filtersForm      // non-nil while the filters are being edited
+	rerunChooser       *rerunChooser     // non-nil while choosing how to rerun
+	deploymentReview   *deploymentReview // non-nil while reviewing a run's deployments
+	dispatchForm       *dispatchForm     // non-nil while picking a workflow to run
+	artifactsBrowser   *artifactsBrowser // non-nil while browsing a run's artifacts
+	artifactPreview    *artifactPreview  // non-nil while an artifact is shown instead of the logs
+	compareBase        *compareSide      // the job marked to compare its logs with another job
+	logsDiff           *logsDiff         // non-nil while comparing the logs of two jobs
+	gutterMode         gutterMode        // what the gutter of the job logs shows next to each line
+	stepOnly           bool              // restrict the logs viewport to the selected step's lines
+	searchOpts         searchOptions     // the modifiers of the logs search
+	searchErr          error             // why the typed search can't be applied
+	searchHighlights   [][]int           // the matches of the applied search
+	searchRows         []int             // the row of the logs viewport of each match
+	searchIdx          int               // the highlighted match, -1 when none is
+	searchHistory      []string          // the searches applied during the session, the latest last
+	searchHistoryIdx   int               // the search recalled from the history
+	searchDraft        string            // the search typed before recalling older ones
+	searchMatches      *searchMatchList  // non-nil while listing the lines the search matches
+	pendingDispatch    *pendingDispatch  // the dispatched workflow to select the run of
+	deepLink           *deepLink         // non-nil until the job, step and line linked to are selected
+	helpOpen           bool
+	help               help.Model
 }
 
 type ModelOpts struct {
 	Flat     bool
+	Host     string // e.g. github.com or a GHES hostname, resolved from gh when empty
 	Repo     string
-	PRNumber string // non-empty when in PR context
-	RunID    string // non-empty when in run mode (no PR context)
+	PRNumber string                      // non-empty when in PR context
+	RunID    string                      // non-empty when in run mode (no PR context)
+	Filters  api.RepoWorkflowRunsFilters // narrows down the runs listed in repo mode
+	JobID    string                      // the job to select on start, in PR or run mode
+	Step     int                         // the number of the step of JobID to select on start
+	Line     int                         // the log line of JobID to scroll to, relative to Step if set
 }
 
 func NewModel(opts ModelOpts) model {
@@ -131,11 +166,7 @@ func NewModel(opts ModelOpts) model {
 	checksList.SetWidth(unfocusedLargePaneWidth)
 
 	vp := viewport.New()
-	vp.LeftGutterFunc = func(info viewport.GutterContext) string {
-		return lipgloss.NewStyle().Foreground(s.colors.faintColor).Render(
-			fmt.Sprintf(" %*d %s ", 5, info.Index+1,
-				lipgloss.NewStyle().Foreground(s.colors.fainterColor).Render("│")))
-	}
+	vp.LeftGutterFunc = newLogsGutter(s, nil)
 	vp.KeyMap.Right = rightKey
 	vp.KeyMap.Left = leftKey
 
@@ -194,7 +225,7 @@ func NewModel(opts ModelOpts) model {
 	}
 
 	m := model{
-		client:            api.New(),
+		client:            api.New(opts.Host),
 		jobsList:          jobsList,
 		runsList:          runsList,
 		stepsList:         stepsList,
@@ -202,6 +233,7 @@ func NewModel(opts ModelOpts) model {
 		prNumber:          opts.PRNumber,
 		repo:              opts.Repo,
 		runID:             opts.RunID,
+		repoFilters:       opts.Filters,
 		runsDelegate:      runsDelegate,
 		jobsDelegate:      jobsDelegate,
 		stepsDelegate:     stepsDelegate,
@@ -217,6 +249,9 @@ func NewModel(opts ModelOpts) model {
 		flat:              flat,
 		focusedPane:       focusedPane,
 		lastFetched:       time.Now(),
+		followLogs:        true,
+		searchIdx:         -1,
+		deepLink:          newDeepLink(opts),
 	}
 	m.help.SetKeys(keys.FullHelp())
 	m.setFocusedPaneStyles()
@@ -268,6 +303,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			repoMsg = msg.(repoModeRunsFetchedMsg)
 		}
 
+		if repoMsg.Err != nil && repoMsg.Page > 1 {
+			// failing to load more runs shouldn't close the app, the user can scroll again to retry
+			log.Error("error when fetching more repo runs", "page", repoMsg.Page, "err", repoMsg.Err)
+			m.loadingMoreRuns = false
+			m.runsList.StopSpinner()
+			return m, nil
+		}
+
 		if repoMsg.Err != nil {
 			log.Debug("error when fetching repo runs", "err", repoMsg.Err)
 			m.err = repoMsg.Err
@@ -277,16 +320,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Sequence(msgCmd, tea.Quit)
 		}
 
-		log.Debug("got repoRunsFetchedMsg", "len(msg.Runs)", len(repoMsg.Runs))
+		log.Debug(
+			"got repoRunsFetchedMsg",
+			"page",
+			repoMsg.Page,
+			"len(msg.Runs)",
+			len(repoMsg.Runs),
+		)
 
 		enrichedMsg := m.enrichRepoModeFetchedRunsWithExistingJobs(repoMsg)
-		m.workflowRuns = enrichedMsg.Runs
+		m.mergeRepoModeRuns(enrichedMsg)
+		m.repoRunsTotalCount = repoMsg.TotalCount
+		if repoMsg.Page > m.repoRunsPage {
+			m.repoRunsPage = repoMsg.Page
+		}
+		if repoMsg.Page > 1 {
+			m.loadingMoreRuns = false
+		}
 		m.lastFetched = time.Now()
 		m.runsList.StopSpinner()
 
 		// repo runs are returned by the REST api which doesn't include each run's jobs,
 		// so we need to restore run's jobs we already fetched in a different call
 		cmds = append(cmds, m.onWorkflowRunsFetched()...)
+		cmds = append(cmds, m.selectDispatchedRun()...)
 
 	case runModeFetchedMsg, runModeIntervalTickMsg:
 		var rmMsg runModeFetchedMsg
@@ -339,7 +396,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if len(wrMsg.pr.Commits.Nodes) > 0 {
 			pageInfo := wrMsg.pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.PageInfo
-			if !pageInfo.HasPreviousPage {
+			// the API doesn't reliably report HasPreviousPage when paginating forward,
+			// so rely on the cursor the page was fetched with instead
+			if wrMsg.cursor == "" {
 				m.workflowRuns = make([]data.WorkflowRun, 0)
 			}
 
@@ -371,28 +430,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.enrichRunWithJobs(msg)
 		cmds = append(cmds, m.onRunChanged()...)
 
+	case runAttemptFetchedMsg:
+		cmds = append(cmds, m.onRunAttemptFetched(msg)...)
+
 	case workflowRunStepsFetchedMsg:
 		cmds = append(cmds, m.enrichRunWithJobsStepsV2(msg)...)
 		cmds = append(cmds, m.updateLists()...)
+		cmds = append(cmds, m.applyDeepLink()...)
 
 	case checkStepsFetchedMsg:
 		m.enrichCheckWithSteps(msg)
 		cmds = append(cmds, m.updateLists()...)
+		cmds = append(cmds, m.applyDeepLink()...)
 
 	case jobLogsFetchedMsg:
 		ji := m.getJobItemById(msg.jobId)
 		if ji != nil {
-			ji.logs = msg.logs
-			ji.logsErr = msg.err
-			ji.logsStderr = msg.stderr
+			ji.fetchingLogs = false
 			ji.loadingLogs = false
 			currJob := m.getSelectedJobItem()
-			if currJob != nil && currJob.job.Id == msg.jobId {
-				cmds = append(cmds, m.renderJobLogs())
-				m.goToErrorInLogs()
+			isSelected := currJob != nil && currJob.job.Id == msg.jobId
+
+			wasPartial := ji.partialLogs
+			ji.logs = msg.logs
+			ji.stepsLines = nil
+			ji.logsErr = msg.err
+			ji.partialLogs = msg.partial
+			if isSelected {
+				cmds = append(cmds, m.refreshJobLogs(ji, wasPartial))
+			} else {
+				ji.renderedLogs, ji.unstyledLogs = nil, nil
 			}
 
 			cmds = append(cmds, m.updateLists()...)
+			cmds = append(cmds, m.applyDeepLink()...)
 		}
 
 	case checkRunOutputFetchedMsg:
@@ -415,25 +486,92 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			log.Error("error rerunning job", "jobId", msg.jobId, "err", msg.err)
 		}
-		ji := m.getJobItemById(msg.jobId)
-		if ji == nil {
+		m.lastFetched = time.Now()
+		cmds = append(cmds, m.makeRestartPollingCmd())
+
+	case reRunRunMsg:
+		if msg.err != nil {
+			log.Error("error rerunning run", "runId", msg.runId, "err", msg.err)
+		}
+		m.lastFetched = time.Now()
+		cmds = append(cmds, m.makeRestartPollingCmd())
+
+	case workflowsFetchedMsg:
+		if m.dispatchForm != nil {
+			m.dispatchForm.setWorkflows(msg.workflows, msg.defaultBranch, msg.err)
+		}
+
+	case dispatchInputsFetchedMsg:
+		f := m.dispatchForm
+		if f == nil || f.workflow == nil || f.workflow.Id != msg.workflowId || f.inputsRef != msg.ref {
 			break
 		}
+		if f.fields != nil {
+			f.setReloadedInputs(msg.inputs, msg.environments, msg.err)
+		} else {
+			f.setInputs(msg.inputs, msg.environments, msg.err)
+		}
 
-		m.lastFetched = time.Now()
-		cmds = append(cmds, m.fetchPRChecksWithInterval())
+	case workflowDispatchedMsg:
+		if msg.err != nil {
+			log.Error("error dispatching workflow", "workflowId", msg.workflowId, "err", msg.err)
+			if m.dispatchForm != nil {
+				m.dispatchForm.submitting = false
+				m.dispatchForm.err = msg.err
+			}
+			break
+		}
+		m.dispatchForm = nil
+		m.pendingDispatch = m.newPendingDispatch(msg.workflowId)
+		cmds = append(cmds, m.makeReconcileCmd())
 
-	case reRunRunMsg:
+	case artifactsFetchedMsg:
+		if m.artifactsBrowser != nil && m.artifactsBrowser.runId == msg.runId {
+			m.artifactsBrowser.setArtifacts(msg.artifacts, msg.err)
+		}
+
+	case artifactDownloadProgressMsg:
+		cmds = append(cmds, m.onArtifactDownloadProgress(msg))
+
+	case artifactDownloadedMsg:
+		m.onArtifactDownloaded(msg)
+
+	case artifactPreviewedMsg:
+		cmds = append(cmds, m.onArtifactPreviewed(msg))
+
+	case jobSummaryFetchedMsg:
+		cmds = append(cmds, m.onJobSummaryFetched(msg))
+
+	case annotationsFetchedMsg:
+		cmds = append(cmds, m.onAnnotationsFetched(msg))
+
+	case editorClosedMsg:
 		if msg.err != nil {
-			log.Error("error rerunning run", "jobId", msg.runId, "err", msg.err)
+			log.Error("error running editor", "err", msg.err)
 		}
-		ri := m.getRunItemById(msg.runId)
-		if ri == nil {
+
+	case pendingDeploymentsFetchedMsg:
+		if m.deploymentReview != nil && m.deploymentReview.runId == msg.runId {
+			m.deploymentReview.setDeployments(msg.deployments, msg.err)
+		}
+
+	case deploymentsReviewedMsg:
+		if msg.err != nil {
+			log.Error("error reviewing deployments", "runId", msg.runId, "err", msg.err)
+			if m.deploymentReview != nil {
+				m.deploymentReview.submitting = false
+				m.deploymentReview.err = msg.err
+			}
 			break
 		}
+		m.deploymentReview = nil
+		cmds = append(cmds, m.onDeploymentsReviewed(msg)...)
 
-		m.lastFetched = time.Now()
-		cmds = append(cmds, m.fetchPRChecksWithInterval())
+	case cancelRunMsg:
+		if msg.err != nil {
+			log.Error("error canceling run", "runId", msg.runId, "force", msg.force, "err", msg.err)
+		}
+		cmds = append(cmds, m.makeReconcileCmd())
 
 	case tea.WindowSizeMsg:
 		log.Info("window size changed", "width", msg.Width, "height", msg.Height)
@@ -441,6 +579,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.setHeights()
 		m.setWidths()
+		if m.logsDiff != nil {
+			m.logsDiff.setSize(m.width, m.getMainContentHeight())
+		}
 
 		m.setFocusedPaneStyles()
 	case tea.KeyPressMsg:
@@ -457,20 +598,109 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		}
 
+		if m.filtersForm != nil {
+			switch {
+			case key.Matches(msg, closeFormKey):
+				m.filtersForm = nil
+			case key.Matches(msg, submitFormKey):
+				filters := m.filtersForm.Value()
+				if err := filters.Validate(); err != nil {
+					m.filtersForm.err = err
+					break
+				}
+				log.Info("applying repo filters", "filters", filters.String())
+				m.repoFilters = filters
+				m.filtersForm = nil
+				return m.refreshAll()
+			default:
+				cmds = append(cmds, m.filtersForm.Update(msg))
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		if m.dispatchForm != nil {
+			if key.Matches(msg, closeFormKey) {
+				m.dispatchForm = nil
+				return m, nil
+			}
+			cmd, workflow, dispatch := m.dispatchForm.Update(msg)
+			cmds = append(cmds, cmd)
+			if workflow != nil {
+				cmds = append(cmds, m.makeFetchDispatchInputsCmd(*workflow, m.dispatchForm.inputsRef))
+			}
+			if dispatch {
+				cmds = append(cmds, m.makeDispatchWorkflowCmd(m.dispatchForm))
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		if m.logsDiff != nil {
+			if key.Matches(msg, closeFormKey) {
+				m.logsDiff = nil
+				return m, nil
+			}
+			return m, m.logsDiff.Update(msg)
+		}
+
+		if m.artifactsBrowser != nil {
+			if key.Matches(msg, closeFormKey) && !m.artifactsBrowser.dir.Focused() {
+				m.artifactsBrowser = nil
+				return m, nil
+			}
+			cmd, action := m.artifactsBrowser.Update(msg)
+			cmds = append(cmds, cmd)
+			switch action {
+			case artifactActionDownload:
+				cmds = append(cmds, m.startArtifactDownload(m.artifactsBrowser))
+			case artifactActionPreview:
+				cmds = append(cmds, m.makePreviewArtifactCmd(m.artifactsBrowser.selected()))
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		if m.deploymentReview != nil {
+			if key.Matches(msg, closeFormKey) {
+				m.deploymentReview = nil
+			} else {
+				cmd, state := m.deploymentReview.Update(msg)
+				cmds = append(cmds, cmd)
+				if state != "" {
+					cmds = append(cmds, m.makeReviewDeploymentsCmd(m.deploymentReview, state))
+				}
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		if m.rerunChooser != nil {
+			if key.Matches(msg, closeFormKey) {
+				m.rerunChooser = nil
+			} else if option := m.rerunChooser.Update(msg); option != nil {
+				cmds = append(cmds, m.applyRerunOption(m.rerunChooser, *option)...)
+				m.rerunChooser = nil
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		if m.searchMatches != nil {
+			if key.Matches(msg, closeFormKey) {
+				m.searchMatches = nil
+			} else if entry := m.searchMatches.Update(msg); entry != nil {
+				m.goToSearchMatch(entry.match)
+				m.searchMatches = nil
+			}
+			return m, nil
+		}
+
 		if m.logsInput.Focused() {
 			if key.Matches(msg, applySearchKey) {
-				ji := m.getSelectedJobItem()
-				if ji != nil {
-					m.logsViewport.SetContentLines(ji.unstyledLogs)
-					highlights := regexp.MustCompile(
-						m.logsInput.Value()).FindAllStringIndex(
-						strings.Join(ji.unstyledLogs, "\n"), -1)
-					m.numHighlights = len(highlights)
-					m.logsViewport.SetHighlights(highlights)
-					m.logsViewport.HighlightNext()
-					m.logsInput.Blur()
+				m.applySearch()
+				if m.logsInput.Focused() {
+					break
 				}
+			} else if m.updateSearchInput(msg) {
+				break
 			} else {
+				m.searchErr = nil
 				m.logsInput, cmd = m.logsInput.Update(msg)
 				cmds = append(cmds, cmd)
 				break
@@ -503,50 +733,69 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if key.Matches(msg, refreshAllKey) {
-			newModel := NewModel(ModelOpts{Repo: m.repo, PRNumber: m.prNumber, RunID: m.runID})
-			newModel.flat = m.flat
-			newModel.focusedPane = m.focusedPane
-			newModel.width = m.width
-			newModel.height = m.height
-			newModel.setHeights()
-			newModel.setWidths()
-
-			newModel.setFocusedPaneStyles()
-
-			m.lastFetched = time.Now()
-			log.Info(
-				"refresh all",
-				"repo",
-				newModel.repo,
-				"prNumber",
-				newModel.prNumber,
-				"runId",
-				newModel.runID,
-			)
-			return newModel, newModel.Init()
+			return m.refreshAll()
 		}
 
-		if key.Matches(msg, rerunKey) {
-			if m.focusedPane != PaneRuns && m.focusedPane != PaneJobs &&
-				m.focusedPane != PaneChecks {
+		if key.Matches(msg, filterRunsKey) && m.mode() == ModeRepo {
+			m.filtersForm = newFiltersForm(m.styles, m.repoFilters)
+			return m, nil
+		}
+
+		if key.Matches(msg, dispatchWorkflowKey) && m.mode() == ModeRepo {
+			m.dispatchForm = newDispatchForm(m.styles)
+			return m, m.makeFetchWorkflowsCmd()
+		}
+
+		if key.Matches(msg, cancelRunKey, forceCancelRunKey) {
+			if run := m.getSelectedRun(); run != nil {
+				cmds = append(cmds, m.cancelRun(run, key.Matches(msg, forceCancelRunKey))...)
+			}
+		}
+
+		if key.Matches(msg, reviewDeploymentsKey) {
+			run := m.getSelectedRun()
+			if run == nil || !run.IsActionsRun() {
 				break
 			}
+			m.deploymentReview = newDeploymentReview(m.styles, m.repo, run)
+			return m, m.makeFetchPendingDeploymentsCmd(run.Id)
+		}
 
-			ri := m.getSelectedRunItem()
-			if ri == nil {
+		if key.Matches(msg, compareKey) {
+			m.compareSelectedJob()
+		}
+
+		if key.Matches(msg, artifactsKey) {
+			run := m.getSelectedRun()
+			if run == nil || !run.IsActionsRun() {
 				break
 			}
+			m.artifactsBrowser = newArtifactsBrowser(m.styles, run)
+			return m, m.makeFetchArtifactsCmd(run.Id)
+		}
 
-			if m.focusedPane == PaneRuns {
-				cmds = append(cmds, m.rerunRun(ri.run.Id)...)
-			} else {
-				ji := m.getSelectedJobItem()
-				if ji == nil {
-					break
+		if key.Matches(msg, rerunKey) {
+			run := m.getSelectedRun()
+			if run == nil || !run.IsActionsRun() {
+				break
+			}
+
+			var job *data.WorkflowJob
+			if m.focusedPane != PaneRuns {
+				if ji := m.getSelectedJobItem(); ji != nil {
+					job = ji.job
 				}
-				rid := ri.run.Id
-				cmds = append(cmds, m.rerunJob(rid, ji.job.Id)...)
 			}
+			m.rerunChooser = newRerunChooser(m.styles, run, job)
+			return m, nil
+		}
+
+		if key.Matches(msg, prevAttemptKey) && !m.flat {
+			cmds = append(cmds, m.cycleRunAttempt(-1))
+		}
+
+		if key.Matches(msg, nextAttemptKey) && !m.flat {
+			cmds = append(cmds, m.cycleRunAttempt(1))
 		}
 
 		if key.Matches(msg, helpKey) {
@@ -554,8 +803,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.setHeights()
 		}
 
+		if key.Matches(msg, summaryTabKey) {
+			cmds = append(cmds, m.toggleLogsTab())
+		}
+
+		if key.Matches(msg, stepOnlyKey) && (m.focusedPane == PaneSteps || m.focusedPane == PaneLogs) {
+			m.toggleStepOnly()
+		}
+
 		if m.focusedPane == PaneLogs && key.Matches(msg, searchKey) {
-			cmds = append(cmds, m.logsInput.Focus())
+			cmds = append(cmds, m.focusSearch())
 		}
 
 		if key.Matches(msg, openPRKey) && m.prWithChecks.Url != "" {
@@ -663,6 +920,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if before != after {
 			cmds = append(cmds, m.onRunChanged()...)
 			cmds = append(cmds, m.updateLists()...)
+			cmds = append(cmds, m.fetchMoreRepoRunsIfNeeded())
 		}
 	case PaneJobs:
 		before := m.jobsList.GlobalIndex()
@@ -681,6 +939,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.onStepChanged()
 		}
 
+	case PaneAnnotations:
+		if msg, ok := msg.(tea.KeyPressMsg); ok {
+			cmds = append(cmds, m.updateAnnotations(msg))
+		}
+
 	case PaneLogs:
 		if msg, ok := msg.(tea.KeyPressMsg); ok {
 			if key.Matches(msg, gotoBottomKey) {
@@ -692,25 +955,60 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			if key.Matches(msg, nextSearchMatchKey) {
-				m.logsViewport.HighlightNext()
+				m.cycleSearchMatch(1)
 			}
 
 			if key.Matches(msg, prevSearchMatchKey) {
-				m.logsViewport.HighlightPrevious()
+				m.cycleSearchMatch(-1)
+			}
+
+			if key.Matches(msg, listSearchMatchesKey) {
+				m.openSearchMatches()
+			}
+
+			if key.Matches(msg, toggleGroupKey) {
+				m.toggleGroupAtTop()
+			}
+
+			if key.Matches(msg, unfoldAllGroupsKey) {
+				m.setAllGroupsFolded(false)
+			}
+
+			if key.Matches(msg, foldAllGroupsKey) {
+				m.setAllGroupsFolded(true)
+			}
+
+			if key.Matches(msg, gutterModeKey) {
+				m.cycleGutterMode()
 			}
 
 			if key.Matches(msg, cancelSearchKey) {
-				m.logsInput.Blur()
-				m.logsInput.Reset()
-				m.numHighlights = 0
-				m.logsViewport.ClearHighlights()
-				ji := m.getSelectedJobItem()
-				if ji != nil {
-					m.logsViewport.SetContentLines(ji.renderedLogs)
+				// esc clears the search first, then closes the artifact preview
+				searching := m.logsInput.Value() != ""
+				m.clearSearch()
+				if m.artifactPreview != nil && !searching {
+					cmds = append(cmds, m.closeArtifactPreview())
+				} else if m.artifactPreview != nil {
+					m.setLogsContentLines(m.artifactPreview.lines)
+				} else if m.showingSummary() {
+					m.setLogsContentLines(strings.Split(m.getSelectedJobItem().renderedSummary, "\n"))
+				} else if ji := m.getSelectedJobItem(); ji != nil {
+					m.setJobLogsContent(ji)
 				}
 			}
 		}
+		top := m.logsViewport.YOffset()
 		m.logsViewport, cmd = m.logsViewport.Update(msg)
+		switch msg.(type) {
+		case tea.KeyPressMsg, tea.MouseWheelMsg:
+			// scrolling up pauses following the logs, scrolling back to the bottom resumes it
+			m.followLogs = m.logsViewport.AtBottom()
+			m.syncStepWithLogs()
+		}
+		if m.logsViewport.YOffset() != top && m.numHighlights > 0 {
+			// the viewport highlights the first match below its top once scrolled
+			m.searchIdx = nearestSearchMatch(m.searchRows, m.logsViewport.YOffset())
+		}
 
 		cmds = append(cmds, cmd)
 	}
@@ -741,7 +1039,10 @@ func (m model) View() tea.View {
 	footer := m.viewFooter()
 
 	panes := ""
-	if m.flat {
+	if m.logsDiff != nil {
+		panes = lipgloss.NewStyle().Height(m.getMainContentHeight()).
+			MaxHeight(m.getMainContentHeight()).Render(m.logsDiff.View())
+	} else if m.flat {
 		panes = m.viewFlatChecks()
 	} else {
 		panes = m.viewHierarchicalChecks()
@@ -774,6 +1075,66 @@ func (m model) View() tea.View {
 		)
 	}
 
+	if m.filtersForm != nil {
+		formView := m.filtersForm.View()
+		row := max(0, m.height/2-lipgloss.Height(formView)/2)
+		col := max(0, m.width/2-lipgloss.Width(formView)/2)
+		layers = append(
+			layers,
+			lipgloss.NewLayer(formView).X(col).Y(row),
+		)
+	}
+
+	if m.searchMatches != nil {
+		matchesView := m.searchMatches.View()
+		row := max(0, m.height/2-lipgloss.Height(matchesView)/2)
+		col := max(0, m.width/2-lipgloss.Width(matchesView)/2)
+		layers = append(
+			layers,
+			lipgloss.NewLayer(matchesView).X(col).Y(row),
+		)
+	}
+
+	if m.rerunChooser != nil {
+		chooserView := m.rerunChooser.View()
+		row := max(0, m.height/2-lipgloss.Height(chooserView)/2)
+		col := max(0, m.width/2-lipgloss.Width(chooserView)/2)
+		layers = append(
+			layers,
+			lipgloss.NewLayer(chooserView).X(col).Y(row),
+		)
+	}
+
+	if m.dispatchForm != nil {
+		formView := m.dispatchForm.View()
+		row := max(0, m.height/2-lipgloss.Height(formView)/2)
+		col := max(0, m.width/2-lipgloss.Width(formView)/2)
+		layers = append(
+			layers,
+			lipgloss.NewLayer(formView).X(col).Y(row),
+		)
+	}
+
+	if m.artifactsBrowser != nil {
+		browserView := m.artifactsBrowser.View()
+		row := max(0, m.height/2-lipgloss.Height(browserView)/2)
+		col := max(0, m.width/2-lipgloss.Width(browserView)/2)
+		layers = append(
+			layers,
+			lipgloss.NewLayer(browserView).X(col).Y(row),
+		)
+	}
+
+	if m.deploymentReview != nil {
+		reviewView := m.deploymentReview.View()
+		row := max(0, m.height/2-lipgloss.Height(reviewView)/2)
+		col := max(0, m.width/2-lipgloss.Width(reviewView)/2)
+		layers = append(
+			layers,
+			lipgloss.NewLayer(reviewView).X(col).Y(row),
+		)
+	}
+
 	comp := lipgloss.NewCompositor(layers...)
 	v.AltScreen = true
 	v.Content = comp.Render()
@@ -797,7 +1158,7 @@ func (m *model) viewHierarchicalChecks() string {
 			panes = append(panes, jobsPane)
 		case PaneSteps:
 			panes = append(panes, stepsPane)
-		case PaneLogs:
+		case PaneLogs, PaneAnnotations:
 			panes = append(panes, m.viewLogs())
 		}
 	} else if m.width != 0 && m.width <= smallScreen {
@@ -808,7 +1169,7 @@ func (m *model) viewHierarchicalChecks() string {
 			panes = append(panes, jobsPane)
 		case PaneSteps:
 			panes = append(panes, stepsPane)
-		case PaneLogs:
+		case PaneLogs, PaneAnnotations:
 			break
 		}
 		panes = append(panes, m.viewLogs())
@@ -839,7 +1200,7 @@ func (m *model) viewFlatChecks() string {
 			panes = append(panes, checksPane)
 		case PaneSteps:
 			panes = append(panes, stepsPane)
-		case PaneLogs:
+		case PaneLogs, PaneAnnotations:
 			panes = append(panes, m.viewLogs())
 		}
 	} else if m.width != 0 && m.width <= smallScreen {
@@ -848,7 +1209,7 @@ func (m *model) viewFlatChecks() string {
 			panes = append(panes, checksPane)
 		case PaneSteps:
 			panes = append(panes, stepsPane)
-		case PaneLogs:
+		case PaneLogs, PaneAnnotations:
 			break
 		}
 		panes = append(panes, m.viewLogs())
@@ -1037,15 +1398,25 @@ func (m *model) viewRepoModeHeader(bgStyle lipgloss.Style, logo string, logoWidt
 	title := bgStyle.Width(contentWidth).Render(lipgloss.JoinVertical(
 		lipgloss.Left,
 		bgStyle.Width(contentWidth).Bold(true).Render(fmt.Sprintf("All %s workflows", m.repo)),
-		bgStyle.Width(contentWidth).
-			Foreground(m.styles.colors.faintColor).
-			Render("Showing runs from all workflows"),
+		m.viewRepoFilters(contentWidth, bgStyle),
 	))
 
 	return m.styles.headerStyle.Width(m.width).Render(
 		lipgloss.JoinHorizontal(lipgloss.Left, title, logo))
 }
 
+func (m *model) viewRepoFilters(width int, bgStyle lipgloss.Style) string {
+	faint := bgStyle.Foreground(m.styles.colors.faintColor)
+	if m.repoFilters.IsEmpty() {
+		return faint.Width(width).Render("Showing runs from all workflows")
+	}
+
+	return bgStyle.Width(width).Render(lipgloss.JoinHorizontal(lipgloss.Top,
+		faint.Render(FilterIcon+" "),
+		bgStyle.Foreground(m.styles.colors.lightColor).Render(m.repoFilters.String()),
+	))
+}
+
 func (m *model) viewFooter() string {
 	bg := lipgloss.NewStyle().Background(m.styles.footerStyle.GetBackground())
 	sFooter := m.styles.footerStyle.Width(m.width)
@@ -1123,7 +1494,12 @@ func (m *model) viewRunModeFooter(bg lipgloss.Style, sFooter lipgloss.Style) str
 }
 
 func (m *model) viewRepoModeFooter(bg lipgloss.Style, sFooter lipgloss.Style) string {
-	return m.renderFooterLayout(bg, sFooter, true, fmt.Sprintf("Watching %s…", m.repo))
+	texts := []string{bg.Render(fmt.Sprintf("Watching %s…", m.repo))}
+	if m.repoRunsTotalCount > 0 {
+		texts = append(texts, bg.Foreground(m.styles.colors.faintColor).Render(
+			fmt.Sprintf(" %d of %d runs loaded", len(m.workflowRuns), m.repoRunsTotalCount)))
+	}
+	return m.renderFooterLayout(bg, sFooter, true, texts...)
 }
 
 func (m *model) appendStatTexts(
@@ -1219,6 +1595,9 @@ func (m *model) shouldShowSteps() bool {
 
 func (m *model) viewLogs() string {
 	title := "Job Logs"
+	if m.artifactPreview != nil {
+		title = "Artifact " + m.artifactPreview.name
+	}
 	w := m.logsWidth()
 	h := m.getMainContentHeight()
 	if m.focusedPane == PaneLogs {
@@ -1231,30 +1610,62 @@ func (m *model) viewLogs() string {
 		title = s.Render(title)
 	}
 
-	if m.logsInput.Value() != "" && !m.logsInput.Focused() {
-		matches := fmt.Sprintf("%d matches", m.numHighlights)
-		if m.numHighlights == 0 {
-			matches = "no matches"
+	if m.artifactPreview == nil && m.hasSummary() {
+		title = m.viewLogsTabs()
+	} else if ji := m.getSelectedJobItem(); m.artifactPreview == nil && ji != nil && ji.summaryErr != nil {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ",
+			m.styles.faintFgStyle.Render(ji.summaryErr.Error()))
+	}
+
+	if m.artifactPreview != nil {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ",
+			m.styles.faintFgStyle.Render("esc to go back to the logs"))
+	} else if ji := m.getSelectedJobItem(); ji != nil && ji.partialLogs {
+		live := lipgloss.NewStyle().Foreground(m.styles.colors.warnColor).Render("● running")
+		if !m.followLogs {
+			live = lipgloss.JoinHorizontal(lipgloss.Top, live,
+				m.styles.faintFgStyle.Render(" paused, G to follow"))
 		}
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ", live)
+	}
+
+	if attempt := m.viewJobAttempt(); m.artifactPreview == nil && attempt != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ", attempt)
+	}
+
+	if m.gutterMode != gutterLineNumbers && m.shownJobLogs() != nil {
 		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ",
-			m.styles.faintFgStyle.Render(matches))
+			m.styles.faintFgStyle.Render(TimeIcon+" "+m.gutterMode.String()))
+	}
+
+	if hint := m.viewStepOnlyHint(); hint != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ", hint)
+	}
+
+	if hint := m.viewCompareHint(); hint != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ", hint)
+	}
+
+	if status := m.viewSearchStatus(); status != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, " ", status)
 	}
 
 	inputView := ""
 	ji := m.getSelectedJobItem()
-	if ji != nil && m.logsViewport.GetContent() != "" && ji.logsStderr == "" {
+	if m.logsViewport.GetContent() != "" && (m.artifactPreview != nil || ji != nil && ji.logsErr == nil) {
 		inputView = lipgloss.NewStyle().
 			Width(w).
 			Border(lipgloss.RoundedBorder(), true).
 			BorderForeground(
 				m.styles.colors.fainterColor).
-			Render(m.logsInput.View())
+			Render(lipgloss.JoinHorizontal(lipgloss.Top, m.logsInput.View(), m.viewSearchOptions()))
 	}
 
 	return lipgloss.NewStyle().
 		Height(h).
 		MaxHeight(h).
-		Render(lipgloss.JoinVertical(lipgloss.Left, title, inputView, m.logsContentView()))
+		Render(lipgloss.JoinVertical(lipgloss.Left, m.viewAnnotations(), title, inputView,
+			m.logsContentView()))
 }
 
 func (m *model) setFocusedPaneStyles() {
@@ -1287,7 +1698,7 @@ func (m *model) setFocusedPaneStyles() {
 		m.setListUnfocusedStyles(&m.runsList, &m.runsDelegate)
 		m.setListUnfocusedStyles(&m.jobsList, &m.jobsDelegate)
 		m.setListFocusedStyles(&m.stepsList, &m.stepsDelegate, PaneSteps)
-	case PaneLogs:
+	case PaneLogs, PaneAnnotations:
 		m.checksDelegate.(*checksDelegate).focused = false
 		m.runsDelegate.(*runsDelegate).focused = false
 		m.jobsDelegate.(*jobsDelegate).focused = false
@@ -1301,7 +1712,7 @@ func (m *model) setFocusedPaneStyles() {
 	w := m.logsWidth()
 	m.logsViewport.SetWidth(w)
 	m.logsInput.SetWidth(int(math.Max(float64(0), float64(
-		w-lipgloss.Width(m.logsInput.Prompt)-2))))
+		w-lipgloss.Width(m.logsInput.Prompt)-lipgloss.Width(m.viewSearchOptions())-2))))
 }
 
 func (m *model) setListFocusedStyles(l *list.Model, delegate *list.ItemDelegate, p pane) {
@@ -1595,7 +2006,7 @@ func (m *model) logsWidth() int {
 		return 0
 	}
 
-	if m.zoomedPane != nil && *m.zoomedPane == PaneLogs {
+	if m.zoomedPane != nil && (*m.zoomedPane == PaneLogs || *m.zoomedPane == PaneAnnotations) {
 		return m.width - 1
 	}
 
@@ -1703,6 +2114,7 @@ func (m *model) enrichRunWithJobsStepsV2(msg workflowRunStepsFetchedMsg) []tea.C
 		}
 
 		ri.jobsItems[jIdx].steps = steps
+		ri.jobsItems[jIdx].stepsLines = nil
 	}
 
 	return cmds
@@ -1724,6 +2136,7 @@ func (m *model) enrichCheckWithSteps(msg checkStepsFetchedMsg) {
 	}
 
 	ci.steps = steps
+	ci.stepsLines = nil
 }
 
 func (m *model) enrichRunWithJobs(msg runJobsFetchedMsg) {
@@ -1733,8 +2146,19 @@ func (m *model) enrichRunWithJobs(msg runJobsFetchedMsg) {
 		return
 	}
 
+	existingJobs := make(map[string]*jobItem)
+	for _, ji := range ri.jobsItems {
+		existingJobs[ji.job.Id] = ji
+	}
+
 	jobs := make([]*jobItem, 0)
 	for _, job := range msg.jobs {
+		// restore previous item if exists so its logs aren't fetched again while it runs
+		if ji, ok := existingJobs[job.Id]; ok {
+			ji.job = &job
+			jobs = append(jobs, ji)
+			continue
+		}
 		si := NewJobItem(job, m.styles)
 		jobs = append(jobs, &si)
 	}
@@ -1742,6 +2166,11 @@ func (m *model) enrichRunWithJobs(msg runJobsFetchedMsg) {
 	log.Info("enriching run with jobs", "runId", ri.run.Id, "len(jobs)", len(jobs))
 	ri.loadingJobs = false
 	ri.run.Jobs = msg.jobs
+	ri.attempts = ri.latestAttempt()
+	if ri.attempt != 0 {
+		// the jobs of a previous attempt are shown
+		return
+	}
 	ri.jobsItems = jobs
 }
 
@@ -1762,7 +2191,7 @@ func (m *model) onCheckChanged() []tea.Cmd {
 		cmds = append(cmds, m.makeFetchCheckStepsCmd(currCheck.job.Id))
 	}
 
-	if !currCheck.initiatedLogsFetch && !currCheck.isStatusInProgress() {
+	if currCheck.shouldFetchLogs() {
 		cmds = append(cmds, m.makeFetchJobLogsCmd())
 	}
 	cmds = append(cmds, m.onJobChanged()...)
@@ -1818,8 +2247,14 @@ func (m *model) onJobChanged() []tea.Cmd {
 	cmds = append(cmds, m.tickSteps()...)
 	cmds = append(cmds, m.logsSpinner.Tick, m.inProgressSpinner.Tick)
 
+	m.followLogs = true
+	m.annotationsCursor = 0
+	m.artifactPreview = nil
 	currJob := m.getSelectedJobItem()
-	if currJob != nil && !currJob.initiatedLogsFetch && !currJob.isStatusInProgress() {
+	if currJob != nil {
+		cmds = append(cmds, m.makeFetchAnnotationsCmd(currJob), m.makeFetchJobSummaryCmd(currJob))
+	}
+	if currJob != nil && currJob.shouldFetchLogs() {
 		log.Debug("onJobChanged - fetching logs", "currJob", currJob.job.Id)
 		cmds = append(cmds, m.makeFetchJobLogsCmd())
 	} else if currJob == nil {
@@ -1827,7 +2262,11 @@ func (m *model) onJobChanged() []tea.Cmd {
 	}
 
 	cmds = append(cmds, m.renderJobLogs())
-	m.goToErrorInLogs()
+	if currJob != nil && currJob.partialLogs {
+		m.logsViewport.GotoBottom()
+	} else {
+		m.goToErrorInLogs()
+	}
 
 	return cmds
 }
@@ -1841,6 +2280,17 @@ func (m *model) onStepChanged() {
 		return
 	}
 
+	// the logs scroll to the step's first line when it's found in them
+	if r, ok := ji.stepsLogs()[step.(*stepItem).step.Number]; ok && m.shownJobLogs() != nil {
+		if m.stepOnly {
+			m.clearSearch()
+			m.setJobLogsContent(ji)
+		}
+		m.followLogs = false
+		m.logsViewport.SetYOffset(ji.rowOfLine(r.start))
+		return
+	}
+
 	if cursor == len(m.stepsList.Items())-1 {
 		m.logsViewport.GotoBottom()
 		return
@@ -1848,13 +2298,27 @@ func (m *model) onStepChanged() {
 
 	for i, log := range ji.logs {
 		if log.Time.After(step.(*stepItem).step.StartedAt) {
-			m.logsViewport.SetYOffset(i - 1)
+			m.logsViewport.SetYOffset(ji.rowOfLine(i) - 1)
 			return
 		}
 	}
 }
 
 func (m *model) renderJobLogs() tea.Cmd {
+	if m.artifactPreview != nil {
+		m.setLogsContentLines(m.artifactPreview.lines)
+		m.setHeights()
+		return nil
+	}
+
+	if m.showingSummary() {
+		m.setLogsContentLines(strings.Split(m.getSelectedJobItem().renderedSummary, "\n"))
+		m.setHeights()
+		return nil
+	}
+
+	// the lines are numbered by the job's folded logs once they're shown
+	m.logsViewport.LeftGutterFunc = newLogsGutter(m.styles, nil)
 	ji := m.getSelectedJobItem()
 	if ji == nil || ji.loadingLogs {
 		m.logsViewport.SetContent("")
@@ -1864,26 +2328,26 @@ func (m *model) renderJobLogs() tea.Cmd {
 		return nil
 	}
 
-	if ji.isStatusInProgress() {
+	if ji.isStatusInProgress() && len(ji.logs) == 0 {
 		return m.inProgressSpinner.Tick
 	}
 
 	if ji.logsErr != nil {
-		m.logsViewport.SetContent(ji.logsStderr)
+		m.logsViewport.SetContent(ji.logsErr.Error())
 		m.setHeights()
 
 		return nil
 	}
 
 	if len(ji.renderedLogs) != 0 {
-		m.logsViewport.SetContentLines(ji.renderedLogs)
+		m.setJobLogsContent(ji)
 		m.setHeights()
 
 		return nil
 	}
 
 	if ji.job.Title != "" || ji.job.Kind == data.JobKindCheckRun ||
-		ji.job.Kind == data.JobKindExternal {
+		ji.job.Kind == data.JobKindExternal || ji.job.Kind == data.JobKindStatusContext {
 		m.logsViewport.SetContent(ji.renderedText)
 		m.logsViewport.SetWidth(5)
 		m.setHeights()
@@ -1892,13 +2356,39 @@ func (m *model) renderJobLogs() tea.Cmd {
 	}
 
 	ji.renderedLogs, ji.unstyledLogs = m.renderLogs(ji)
-	m.logsViewport.SetContentLines(ji.renderedLogs)
+	m.setJobLogsContent(ji)
 	m.setHeights()
 
 	return nil
 }
 
+func (m *model) clearSearch() {
+	m.logsInput.Blur()
+	m.logsInput.Reset()
+	m.resetSearchMatches()
+	m.logsViewport.ClearHighlights()
+}
+
+// searchableLogs returns the unstyled lines shown in the logs viewport
+func (m *model) searchableLogs() ([]string, bool) {
+	if m.artifactPreview != nil {
+		return m.artifactPreview.lines, true
+	}
+	if m.showingSummary() {
+		return m.summaryLines(), true
+	}
+	ji := m.getSelectedJobItem()
+	if ji == nil {
+		return nil, false
+	}
+	return ji.shownUnstyledLogs, true
+}
+
 func (m *model) logsContentView() string {
+	if m.artifactPreview != nil || m.showingSummary() {
+		return m.logsViewportView()
+	}
+
 	if m.prWithChecks.Number != 0 && len(m.prWithChecks.Commits.Nodes) > 0 &&
 		m.prWithChecks.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.CheckRunCount == 0 {
 		return m.fullScreenMessageView(
@@ -1932,7 +2422,7 @@ func (m *model) logsContentView() string {
 		return m.noLogsView("This job was skipped")
 	}
 
-	if ji.isStatusInProgress() {
+	if ji.isStatusInProgress() && len(ji.logs) == 0 {
 		text := ""
 		if ji.job.State == api.StatusWaiting && ji.job.PendingEnv != "" {
 			text = lipgloss.NewStyle().Foreground(
@@ -1942,9 +2432,18 @@ func (m *model) logsContentView() string {
 			text = "This job is still in progress"
 		}
 
-		return m.fullScreenMessageView(
-			m.renderFullScreenLogsSpinner(text, "view the job on github.com"),
-		)
+		view := m.renderFullScreenLogsSpinner(text, "view the job on github.com")
+		if steps := m.viewStepsProgress(ji); steps != "" {
+			view = lipgloss.JoinVertical(lipgloss.Center, view, "", steps)
+		}
+		if ji.job.State == api.StatusWaiting {
+			view = lipgloss.JoinVertical(lipgloss.Center, view,
+				lipgloss.JoinHorizontal(lipgloss.Top, m.styles.faintFgStyle.Render("Press "),
+					m.styles.keyStyle.Render("D"),
+					m.styles.faintFgStyle.Render(" to review the deployment")))
+		}
+
+		return m.fullScreenMessageView(view)
 	}
 
 	if ji.loadingLogs || (ji.loadingSteps && len(ji.steps) == 0) {
@@ -1957,17 +2456,30 @@ func (m *model) logsContentView() string {
 			m.styles.faintFgStyle.Bold(true).Render("This job was cancelled")))
 	}
 
-	if ji.logsErr != nil && strings.Contains(ji.logsStderr, "HTTP 410:") {
+	switch {
+	case errors.Is(ji.logsErr, api.ErrLogsExpired):
 		return m.fullScreenMessageView(
 			"The logs for this run have expired and are no longer available.",
 		)
-	}
-
-	if ji.logsErr != nil && strings.Contains(ji.logsStderr, "is still in progress;") {
+	case errors.Is(ji.logsErr, api.ErrLogsInProgress):
 		return m.fullScreenMessageView(m.renderFullScreenLogsSpinner(
 			"This run is still in progress", "view the run on github.com"))
+	case errors.Is(ji.logsErr, api.ErrLogsNotFound):
+		return m.noLogsView("No logs were found for this job")
+	case errors.Is(ji.logsErr, api.ErrLogsForbidden):
+		return m.fullScreenMessageView(lipgloss.JoinVertical(lipgloss.Center,
+			m.styles.faintFgStyle.Render(art.StopSign),
+			m.styles.faintFgStyle.Bold(true).Render(
+				"You don't have permission to view the logs of this job"),
+			m.styles.faintFgStyle.Render(
+				"make sure your token can read the repo's actions"),
+		))
 	}
 
+	return m.logsViewportView()
+}
+
+func (m *model) logsViewportView() string {
 	if m.isScrollbarVisible() {
 		return lipgloss.JoinHorizontal(lipgloss.Top,
 			m.logsViewport.View(),
@@ -2028,54 +2540,170 @@ func (m *model) getJobItemById(jobId string) *jobItem {
 	return nil
 }
 
+// getSelectedRun returns the run of the selected run, job or check.
+func (m *model) getSelectedRun() *data.WorkflowRun {
+	if !m.flat {
+		if ri := m.getSelectedRunItem(); ri != nil {
+			return ri.run
+		}
+		return nil
+	}
+
+	ci := m.getSelectedCheckItem()
+	if ci == nil {
+		return nil
+	}
+	for i, run := range m.workflowRuns {
+		for _, job := range run.Jobs {
+			if job.Id == ci.job.Id {
+				return &m.workflowRuns[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (m *model) jobItemsOfRun(runId string) []*jobItem {
+	if ri := m.getRunItemById(runId); ri != nil && !m.flat {
+		return ri.jobsItems
+	}
+
+	items := make([]*jobItem, 0)
+	for _, run := range m.workflowRuns {
+		if run.Id != runId {
+			continue
+		}
+		for _, job := range run.Jobs {
+			if ji := m.getJobItemById(job.Id); ji != nil {
+				items = append(items, ji)
+			}
+		}
+	}
+	return items
+}
+
+// refreshJobLogs shows the newly fetched logs of the selected job. The logs of a job that
+// was running stay scrolled to the bottom while they're followed.
+func (m *model) refreshJobLogs(ji *jobItem, wasPartial bool) tea.Cmd {
+	if m.artifactPreview != nil || m.showingSummary() {
+		// the logs are rendered once they're shown again
+		return nil
+	}
+
+	ji.renderedLogs, ji.unstyledLogs, ji.errorLine = nil, nil, 0
+	cmd := m.renderJobLogs()
+	if ji.partialLogs || (wasPartial && ji.errorLine == 0) {
+		if m.followLogs {
+			m.logsViewport.GotoBottom()
+		}
+	} else {
+		m.goToErrorInLogs()
+	}
+	return cmd
+}
+
 func (m *model) renderLogs(ji *jobItem) ([]string, []string) {
 	defer utils.TimeTrack(time.Now(), "rendering logs")
+	ji.annotationLines = matchAnnotations(ji.logs, ji.annotations)
 	w := m.logsViewport.Width() - m.styles.scrollbarStyle.GetWidth()
-	expand := ExpandSymbol + " "
 	lines := make([]string, 0)
 	unstyledLines := make([]string, 0)
-	for i, log := range ji.logs {
-		rendered := log.Log
-		unstyled := ansi.Strip(log.Log)
-		switch log.Kind {
-		case data.LogKindError:
+	for i := range ji.logs {
+		if ji.logs[i].Kind == data.LogKindError {
 			ji.errorLine = i
-			rendered = strings.Replace(rendered, parser.ErrorMarker, "", 1)
-			unstyled = rendered
-			rendered = m.styles.errorBgStyle.Width(w).Render(lipgloss.JoinHorizontal(lipgloss.Top,
-				m.styles.errorTitleStyle.Render("Error: "), m.styles.errorStyle.Render(rendered)))
-		case data.LogKindCommand:
-			rendered = strings.Replace(rendered, parser.CommandMarker, "", 1)
-			unstyled = rendered
-			rendered = m.styles.commandStyle.Render(rendered)
-		case data.LogKindGroupStart:
-			rendered = strings.Replace(rendered, parser.GroupStartMarker, expand, 1)
-			unstyled = rendered
-			rendered = m.styles.groupStartMarkerStyle.Render(rendered)
-		case data.LogKindJobCleanup:
-			rendered = m.styles.stepStartMarkerStyle.Render(rendered)
-		case data.LogKindStepStart:
-			rendered = strings.Replace(rendered, parser.GroupStartMarker, expand, 1)
-			unstyled = rendered
-			rendered = m.styles.stepStartMarkerStyle.Render(rendered)
-		case data.LogKindStepNone:
-			sep := ""
-			unstyledSep := ""
-			if log.Depth > 0 {
-				dm := strings.Repeat(
-					fmt.Sprintf("%s  ", Separator), log.Depth)
-				unstyledSep = dm
-				sep = m.styles.separatorStyle.Render(dm)
-			}
-			unstyled = unstyledSep + unstyled
-			rendered = sep + rendered
 		}
+		// annotated lines are prefixed with the annotation's level
+		if annotation, ok := ji.annotationAtLine(i); ok {
+			rendered, unstyled := renderLogLine(ji.logs[i], w-2, m.styles)
+			lines = append(lines, m.annotationGlyph(annotation.Level)+" "+rendered)
+			unstyledLines = append(unstyledLines, annotationIcon(annotation.Level)+" "+unstyled)
+			continue
+		}
+		rendered, unstyled := renderLogLine(ji.logs[i], w, m.styles)
 		lines = append(lines, rendered)
 		unstyledLines = append(unstyledLines, unstyled)
 	}
 	return lines, unstyledLines
 }
 
+// renderLogLine styles a parsed log line by its kind and returns it along with its
+// unstyled text. Errors span the given width, pass 0 to not pad them.
+func renderLogLine(log data.LogsWithTime, w int, s styles) (string, string) {
+	expand := ExpandSymbol + " "
+	rendered := log.Log
+	unstyled := ansi.Strip(log.Log)
+	switch log.Kind {
+	case data.LogKindError:
+		title, message := logMessageParts(log, parser.ErrorMarker, "Error")
+		unstyled = title + ansi.Strip(message)
+		rendered = s.errorBgStyle.Width(w).Render(lipgloss.JoinHorizontal(lipgloss.Top,
+			s.errorTitleStyle.Render(title), s.errorStyle.Render(message)))
+	case data.LogKindWarning:
+		title, message := logMessageParts(log, parser.WarningMarker, "Warning")
+		unstyled = title + ansi.Strip(message)
+		rendered = s.warningBgStyle.Width(w).Render(lipgloss.JoinHorizontal(lipgloss.Top,
+			s.warningTitleStyle.Render(title), s.warningStyle.Render(message)))
+	case data.LogKindNotice:
+		title, message := logMessageParts(log, parser.NoticeMarker, "Notice")
+		unstyled = title + ansi.Strip(message)
+		rendered = s.noticeTitleStyle.Render(title) + message
+	case data.LogKindDebug:
+		rendered = strings.Replace(unstyled, parser.DebugMarker, "Debug: ", 1)
+		unstyled = rendered
+		rendered = s.debugLogStyle.Render(rendered)
+	case data.LogKindSection:
+		rendered = strings.Replace(rendered, parser.SectionMarker, "", 1)
+		unstyled = ansi.Strip(rendered)
+		rendered = s.stepStartMarkerStyle.Render(rendered)
+	case data.LogKindMask, data.LogKindStopCommands:
+		rendered = s.faintFgStyle.Render(unstyled)
+	case data.LogKindCommand:
+		rendered = strings.Replace(rendered, parser.CommandMarker, "", 1)
+		unstyled = rendered
+		rendered = s.commandStyle.Render(rendered)
+	case data.LogKindGroupStart:
+		rendered = strings.Replace(rendered, parser.GroupStartMarker, expand, 1)
+		unstyled = rendered
+		rendered = s.groupStartMarkerStyle.Render(rendered)
+	case data.LogKindJobCleanup:
+		rendered = s.stepStartMarkerStyle.Render(rendered)
+	case data.LogKindStepStart:
+		rendered = strings.Replace(rendered, parser.GroupStartMarker, expand, 1)
+		unstyled = rendered
+		rendered = s.stepStartMarkerStyle.Render(rendered)
+	case data.LogKindStepNone:
+		sep := ""
+		unstyledSep := ""
+		if log.Depth > 0 {
+			dm := strings.Repeat(
+				fmt.Sprintf("%s  ", Separator), log.Depth)
+			unstyledSep = dm
+			sep = s.separatorStyle.Render(dm)
+		}
+		unstyled = unstyledSep + unstyled
+		rendered = sep + rendered
+	}
+	return rendered, unstyled
+}
+
+// logMessageParts splits an error, warning or notice line into its title, e.g.
+// "Error (main.go:12): ", and its message without the marker
+func logMessageParts(log data.LogsWithTime, marker string, kind string) (string, string) {
+	_, message, found := strings.Cut(log.Log, marker)
+	if !found {
+		message = log.Log
+	}
+
+	title := kind
+	if log.Properties.Title != "" {
+		title = log.Properties.Title
+	}
+	if location := log.Properties.Location(); location != "" {
+		title += " (" + location + ")"
+	}
+	return title + ": ", message
+}
+
 func (m *model) getFocusedPaneWidth(l *list.Model, p pane) int {
 	if m.zoomedPane != nil && p == *m.zoomedPane {
 		return m.width - 1
@@ -2157,7 +2785,7 @@ func (m *model) goToErrorInLogs() {
 				break
 			}
 		}
-		m.logsViewport.SetYOffset(currJob.errorLine)
+		m.logsViewport.SetYOffset(m.revealLogLine(currJob, currJob.errorLine))
 	} else {
 		m.logsViewport.GotoTop()
 	}
@@ -2167,7 +2795,7 @@ func (m *model) getLogsViewportHeight() int {
 	h := m.getMainContentHeight()
 
 	// TODO: take borders from logsInput view
-	vph := h - paneTitleHeight
+	vph := h - paneTitleHeight - m.annotationsHeight()
 	if m.logsViewport.GetContent() != "" {
 		vph -= lipgloss.Height(m.logsInput.View()) + 2 // borders
 	}
@@ -2198,7 +2826,18 @@ func (m *model) setWidths() {
 	m.help.SetWidth(m.width)
 	w := m.logsWidth()
 	m.logsViewport.SetWidth(w)
-	m.logsInput.SetWidth(w - 10)
+	m.logsInput.SetWidth(w - lipgloss.Width(m.viewSearchOptions()) - 10)
+}
+
+// viewStepsProgress renders the status of the job's steps, which is what there is to show
+// of a running job's progress while GitHub doesn't serve its logs
+func (m *model) viewStepsProgress(ji *jobItem) string {
+	rows := make([]string, 0, len(ji.steps))
+	for _, si := range ji.steps {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, si.viewConclusion(), " ",
+			si.step.Name, " ", m.styles.faintFgStyle.Render(si.Description())))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 func (m *model) renderFullScreenLogsSpinner(message string, cta string) string {
@@ -2209,7 +2848,7 @@ func (m *model) renderFullScreenLogsSpinner(message string, cta string) string {
 			"  ",
 			lipgloss.NewStyle().Foreground(m.styles.colors.warnColor).Render(message)),
 		"",
-		m.styles.faintFgStyle.Render("(logs will be available when it is complete)"),
+		m.styles.faintFgStyle.Render("(GitHub only serves the logs once the job is complete)"),
 		"",
 		lipgloss.JoinHorizontal(lipgloss.Top, m.styles.faintFgStyle.Render("Press "),
 			m.styles.keyStyle.Render("o"),
@@ -2246,7 +2885,7 @@ func (m *model) onWorkflowRunsFetched() []tea.Cmd {
 			}
 		}
 
-		if before != nil && !before.initiatedLogsFetch {
+		if before != nil && before.shouldFetchLogs() {
 			cmds = append(cmds, m.logsSpinner.Tick, m.makeFetchJobLogsCmd())
 		}
 	} else {
@@ -2280,12 +2919,18 @@ func (m *model) onWorkflowRunsFetched() []tea.Cmd {
 		}
 
 		currJob := m.getSelectedJobItem()
-		if currJob != nil && !currJob.initiatedLogsFetch {
+		if currJob != nil && currJob.shouldFetchLogs() {
 			cmds = append(cmds, m.logsSpinner.Tick, m.makeFetchJobLogsCmd())
 		}
 	}
 
 	cmds = append(cmds, m.updateLists()...)
+	cmds = append(cmds, m.applyDeepLink()...)
+
+	// annotations and summaries are only available once the selected job completes
+	if currJob := m.getSelectedJobItem(); currJob != nil {
+		cmds = append(cmds, m.makeFetchAnnotationsCmd(currJob), m.makeFetchJobSummaryCmd(currJob))
+	}
 
 	return cmds
 }
@@ -2348,7 +2993,10 @@ func (m *model) buildHierachicalChecksLists() []tea.Cmd {
 			jobs = append(jobs, ji)
 		}
 
-		ri.jobsItems = jobs
+		// keep showing the jobs of the previous attempt the user is looking at
+		if ri.attempt == 0 {
+			ri.jobsItems = jobs
+		}
 	}
 
 	for i, item := range m.runsList.Items() {
@@ -2407,8 +3055,9 @@ func (m *model) stopSpinners() {
 }
 
 func (m *model) resetStepsState() {
+	m.stepOnly = false
 	m.logsViewport.ClearHighlights()
-	m.numHighlights = 0
+	m.resetSearchMatches()
 	m.logsInput.Reset()
 	m.stepsList.ResetSelected()
 	m.stepsList.ResetFilter()
@@ -2442,6 +3091,62 @@ func (m *model) mode() mode {
 	return ModeRepo
 }
 
+// refreshAll throws away all fetched data and starts fetching from scratch,
+// keeping the layout and the repo filters
+func (m *model) refreshAll() (tea.Model, tea.Cmd) {
+	newModel := NewModel(ModelOpts{
+		Host:     m.client.Host(),
+		Repo:     m.repo,
+		PRNumber: m.prNumber,
+		RunID:    m.runID,
+		Filters:  m.repoFilters,
+	})
+	newModel.flat = m.flat
+	newModel.focusedPane = m.focusedPane
+	newModel.width = m.width
+	newModel.height = m.height
+	newModel.setHeights()
+	newModel.setWidths()
+
+	newModel.setFocusedPaneStyles()
+
+	m.lastFetched = time.Now()
+	log.Info(
+		"refresh all",
+		"repo",
+		newModel.repo,
+		"prNumber",
+		newModel.prNumber,
+		"runId",
+		newModel.runID,
+	)
+	return newModel, newModel.Init()
+}
+
+func (m *model) hasMoreRepoRuns() bool {
+	return len(m.workflowRuns) < m.repoRunsTotalCount
+}
+
+// fetchMoreRepoRunsIfNeeded loads the next page of runs in repo mode
+// once the last run in the list is selected
+func (m *model) fetchMoreRepoRunsIfNeeded() tea.Cmd {
+	if m.mode() != ModeRepo || m.loadingMoreRuns || !m.hasMoreRepoRuns() {
+		return nil
+	}
+
+	if m.runsList.IsFiltered() ||
+		m.runsList.GlobalIndex() < len(m.runsList.Items())-1 {
+		return nil
+	}
+
+	log.Info("reached the end of the runs list - fetching more", "page", m.repoRunsPage+1)
+	m.loadingMoreRuns = true
+	return tea.Batch(
+		m.runsList.StartSpinner(),
+		m.makeGetNextPageRepoChecksCmd(m.repoRunsPage+1),
+	)
+}
+
 func (m *model) enrichRepoModeFetchedRunsWithExistingJobs(
 	msg repoModeRunsFetchedMsg,
 ) repoModeRunsFetchedMsg {
@@ -2471,7 +3176,9 @@ func (m *model) enrichRepoModeFetchedRunsWithExistingJobs(
 		enriched[i] = run
 	}
 	return repoModeRunsFetchedMsg{
-		Repo: msg.Repo,
-		Runs: enriched,
+		Repo:       msg.Repo,
+		Runs:       enriched,
+		Page:       msg.Page,
+		TotalCount: msg.TotalCount,
 	}
 }
@@ -0,0 +1,309 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/log/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+// Exit codes of a headless watch, matching `gh pr checks --watch`.
+const (
+	WatchExitPass    = 0
+	WatchExitFail    = 1
+	WatchExitPending = 8
+)
+
+type WatchOpts struct {
+	Out      io.Writer
+	IsTTY    bool          // redraw the status table in place instead of appending lines
+	Width    int           // the terminal width, rows are truncated to it when set
+	Timeout  time.Duration // give up and exit with WatchExitPending after it, 0 waits forever
+	Interval time.Duration // defaults to the refresh interval of the tui
+}
+
+// Watch polls the checks of a PR, a run or a repo's latest runs without
+// starting the tui and prints their status until they all conclude.
+// It returns the exit code to report to the shell.
+func Watch(opts ModelOpts, wo WatchOpts) (int, error) {
+	m := NewModel(opts)
+	if wo.Interval == 0 {
+		wo.Interval = refreshInterval
+	}
+
+	var deadline time.Time
+	if wo.Timeout > 0 {
+		deadline = time.Now().Add(wo.Timeout)
+	}
+
+	w := watcher{styles: m.styles, opts: wo, lastBuckets: make(map[string]data.CheckBucket)}
+	var runs []data.WorkflowRun
+	fetched := false
+	for {
+		fetchedRuns, err := m.fetchWatchedRuns()
+		if err != nil {
+			if !fetched {
+				return WatchExitFail, err
+			}
+			log.Warn("failed refreshing checks, retrying", "err", err)
+		} else {
+			fetched = true
+			runs = fetchedRuns
+			w.print(runs)
+		}
+
+		bucket := watchedBucket(runs)
+		if err == nil && bucket != data.CheckBucketPending {
+			w.printSummary(runs)
+			if bucket == data.CheckBucketFail {
+				return WatchExitFail, nil
+			}
+			return WatchExitPass, nil
+		}
+
+		wait := wo.Interval
+		if !deadline.IsZero() {
+			left := time.Until(deadline)
+			if left <= 0 {
+				w.printSummary(runs)
+				return WatchExitPending, nil
+			}
+			wait = min(wait, left)
+		}
+		time.Sleep(wait)
+	}
+}
+
+// fetchWatchedRuns reuses the fetching of the tui to get the runs of the current mode.
+// In repo mode only the latest run of every workflow for a single commit is watched: the
+// one passed with --sha, or else the latest commit with runs matching the filters, so
+// stale runs of other commits don't decide the result.
+func (m *model) fetchWatchedRuns() ([]data.WorkflowRun, error) {
+	switch {
+	case m.prNumber != "":
+		m.workflowRuns = make([]data.WorkflowRun, 0)
+		cursor := ""
+		for {
+			msg := m.fetchPRChecksWithCursor(m.prNumber, cursor).(workflowRunsFetchedMsg)
+			if msg.err != nil {
+				return nil, msg.err
+			}
+			if len(msg.pr.Commits.Nodes) == 0 {
+				return m.workflowRuns, nil
+			}
+			m.mergeWorkflowRuns(msg)
+
+			pageInfo := msg.pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.PageInfo
+			if !pageInfo.HasNextPage {
+				return m.workflowRuns, nil
+			}
+			cursor = pageInfo.EndCursor
+		}
+	case m.runID != "":
+		msg := m.fetchRun().(runModeFetchedMsg)
+		return msg.runs, msg.err
+	default:
+		runs, err := m.fetchWatchedRepoRuns()
+		if err != nil || m.repoFilters.HeadSHA != "" || len(runs) == 0 {
+			return runs, err
+		}
+
+		// the commit is kept for the rest of the watch, as later pushes aren't what's waited for
+		m.repoFilters.HeadSHA = runs[0].HeadSha
+		log.Info("watching the runs of the latest commit", "sha", m.repoFilters.HeadSHA)
+		return m.fetchWatchedRepoRuns()
+	}
+}
+
+// fetchWatchedRepoRuns fetches the latest run of every workflow matching the repo filters
+func (m *model) fetchWatchedRepoRuns() ([]data.WorkflowRun, error) {
+	msg := m.fetchRepoChecksPage(1).(repoModeRunsFetchedMsg)
+	if msg.Err != nil {
+		return nil, msg.Err
+	}
+	seen := make(map[int]bool)
+	runs := make([]data.WorkflowRun, 0)
+	for _, run := range msg.Runs {
+		if seen[run.WorkflowId] {
+			continue
+		}
+		seen[run.WorkflowId] = true
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// watchedChecks flattens runs to the checks a watch reports on. Runs whose jobs
+// weren't fetched are reported as a single check.
+func watchedChecks(run data.WorkflowRun) []data.WorkflowJob {
+	if len(run.Jobs) > 0 {
+		return run.Jobs
+	}
+	return []data.WorkflowJob{{
+		Id:        run.Id,
+		Name:      run.Name,
+		StartedAt: run.StartedAt,
+		Bucket:    run.Bucket,
+	}}
+}
+
+// watchedBucket reduces all checks to a single bucket: fail if any check failed,
+// was canceled or requires an action, pending if any is still running and pass otherwise.
+// No checks is pending too, as they may not have been created yet right after a push.
+func watchedBucket(runs []data.WorkflowRun) data.CheckBucket {
+	pending := len(runs) == 0
+	for _, run := range runs {
+		for _, job := range watchedChecks(run) {
+			switch job.Bucket {
+			case data.CheckBucketFail, data.CheckBucketCancel, data.CheckBucketActionRequired:
+				return data.CheckBucketFail
+			case data.CheckBucketPending:
+				pending = true
+			}
+		}
+	}
+	if pending {
+		return data.CheckBucketPending
+	}
+	return data.CheckBucketPass
+}
+
+func checkDuration(job data.WorkflowJob) string {
+	if job.StartedAt.IsZero() {
+		return ""
+	}
+	end := job.CompletedAt
+	if end.IsZero() || job.Bucket == data.CheckBucketPending {
+		end = time.Now()
+	}
+	return end.Sub(job.StartedAt).Round(time.Second).String()
+}
+
+type watcher struct {
+	styles styles
+	opts   WatchOpts
+	// the number of lines of the table drawn last, to redraw it in place
+	printedLines int
+	// the last bucket printed per check, to only append changes when not on a TTY
+	lastBuckets map[string]data.CheckBucket
+}
+
+func (w *watcher) print(runs []data.WorkflowRun) {
+	if w.opts.IsTTY {
+		w.printTable(runs)
+		return
+	}
+
+	now := time.Now().Format(time.TimeOnly)
+	for _, run := range runs {
+		for _, job := range watchedChecks(run) {
+			key := run.Id + "/" + job.Id
+			if last, ok := w.lastBuckets[key]; ok && last == job.Bucket {
+				continue
+			}
+			w.lastBuckets[key] = job.Bucket
+
+			line := fmt.Sprintf("%s  %-8s  %s", now, job.Bucket, checkName(run, job))
+			if d := checkDuration(job); d != "" && job.Bucket != data.CheckBucketPending {
+				line += "  " + d
+			}
+			lipgloss.Fprintln(w.opts.Out, line)
+		}
+	}
+}
+
+func checkName(run data.WorkflowRun, job data.WorkflowJob) string {
+	if job.Id == run.Id || job.Name == run.Name {
+		return run.Name
+	}
+	return run.Name + " / " + job.Name
+}
+
+func (w *watcher) printTable(runs []data.WorkflowRun) {
+	faint := w.styles.faintFgStyle
+	lines := make([]string, 0)
+	for _, run := range runs {
+		checks := watchedChecks(run)
+		if len(run.Jobs) == 0 {
+			lines = append(lines, w.tableRow("", checks[0], run.Name))
+			continue
+		}
+
+		lines = append(lines, bucketToIcon(run.Bucket, lipgloss.NewStyle(), w.styles)+" "+
+			lipgloss.NewStyle().Bold(true).Render(run.Name))
+		for _, job := range checks {
+			lines = append(lines, w.tableRow("  ", job, job.Name))
+		}
+	}
+	lines = append(lines, "", faint.Render(summarize(runs)+
+		" • updated "+time.Now().Format(time.TimeOnly)))
+
+	if w.opts.Width > 0 {
+		for i, line := range lines {
+			lines[i] = ansi.Truncate(line, w.opts.Width, "…")
+		}
+	}
+
+	out := strings.Builder{}
+	if w.printedLines > 0 {
+		out.WriteString("\r" + ansi.CursorUp(w.printedLines) + ansi.EraseScreenBelow)
+	}
+	out.WriteString(strings.Join(lines, "\n") + "\n")
+	lipgloss.Fprint(w.opts.Out, out.String())
+	w.printedLines = len(lines)
+}
+
+func (w *watcher) tableRow(indent string, job data.WorkflowJob, name string) string {
+	row := indent + bucketToIcon(job.Bucket, lipgloss.NewStyle(), w.styles) + " " + name
+	if d := checkDuration(job); d != "" {
+		row += " " + w.styles.faintFgStyle.Render(d)
+	}
+	return row
+}
+
+func (w *watcher) printSummary(runs []data.WorkflowRun) {
+	if w.opts.IsTTY {
+		return
+	}
+	lipgloss.Fprintln(w.opts.Out, summarize(runs))
+}
+
+// summarize counts the checks per bucket, e.g. "1 failing, 2 pending, 10 successful".
+func summarize(runs []data.WorkflowRun) string {
+	counts := make(map[data.CheckBucket]int)
+	total := 0
+	for _, run := range runs {
+		for _, job := range watchedChecks(run) {
+			counts[job.Bucket]++
+			total++
+		}
+	}
+	if total == 0 {
+		return "no checks reported"
+	}
+
+	parts := make([]string, 0)
+	for _, c := range []struct {
+		bucket data.CheckBucket
+		label  string
+	}{
+		{data.CheckBucketFail, "failing"},
+		{data.CheckBucketCancel, "canceled"},
+		{data.CheckBucketActionRequired, "action required"},
+		{data.CheckBucketPending, "pending"},
+		{data.CheckBucketPass, "successful"},
+		{data.CheckBucketSkipping, "skipped"},
+		{data.CheckBucketNeutral, "neutral"},
+	} {
+		if counts[c.bucket] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[c.bucket], c.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dlvhdr/gh-enhance/internal/data"
+)
+
+func TestWatchedBucket(t *testing.T) {
+	run := func(buckets ...data.CheckBucket) data.WorkflowRun {
+		r := data.WorkflowRun{Id: "1", Name: "ci"}
+		for i, b := range buckets {
+			r.Jobs = append(r.Jobs, data.WorkflowJob{Id: strconv.Itoa(i), Bucket: b})
+		}
+		return r
+	}
+
+	tests := []struct {
+		name string
+		runs []data.WorkflowRun
+		want data.CheckBucket
+	}{
+		{"no checks yet", nil, data.CheckBucketPending},
+		{"all passed", []data.WorkflowRun{
+			run(data.CheckBucketPass, data.CheckBucketSkipping, data.CheckBucketNeutral),
+		}, data.CheckBucketPass},
+		{"pending", []data.WorkflowRun{
+			run(data.CheckBucketPass, data.CheckBucketPending),
+		}, data.CheckBucketPending},
+		{"failed while pending", []data.WorkflowRun{
+			run(data.CheckBucketPending), run(data.CheckBucketFail),
+		}, data.CheckBucketFail},
+		{"canceled", []data.WorkflowRun{run(data.CheckBucketCancel)}, data.CheckBucketFail},
+		{"run without jobs", []data.WorkflowRun{
+			{Id: "2", Name: "lint", Bucket: data.CheckBucketPending},
+		}, data.CheckBucketPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := watchedBucket(tt.runs); got != tt.want {
+				t.Errorf("expected bucket %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWatchAppendsOnlyChangedChecks(t *testing.T) {
+	out := strings.Builder{}
+	w := watcher{
+		styles:      makeStyles(),
+		opts:        WatchOpts{Out: &out},
+		lastBuckets: make(map[string]data.CheckBucket),
+	}
+	runs := []data.WorkflowRun{{Id: "1", Name: "ci", Jobs: []data.WorkflowJob{
+		{Id: "10", Name: "build", Bucket: data.CheckBucketPass},
+		{Id: "11", Name: "test", Bucket: data.CheckBucketPending},
+	}}}
+
+	w.print(runs)
+	runs[0].Jobs[1].Bucket = data.CheckBucketFail
+	w.print(runs)
+	w.printSummary(runs)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), out.String())
+	}
+	for i, want := range []string{"pass      ci / build", "pending   ci / test", "fail      ci / test"} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("expected line %d to contain %q, got %q", i, want, lines[i])
+		}
+	}
+	if lines[3] != "1 failing, 1 successful" {
+		t.Errorf("unexpected summary %q", lines[3])
+	}
+}